@@ -5,6 +5,7 @@ package conf
 import (
 	"bytes"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -41,7 +42,9 @@ type ImmutableConfiguration struct {
 	healthProbePort         int
 	waitDurationForResource time.Duration
 	errorTimeout            time.Duration
+	unregisterTimeout       time.Duration
 	featureFlags            ImmutableFeatureFlags
+	kubernetesVersions      ImmutableKubernetesVersions
 
 	// This is only stored to be able to log it at app start-up: Do not use this field it is not immutable
 	config *MutableConfiguration
@@ -56,12 +59,18 @@ type MutableConfiguration struct {
 	// Changing the ports should only be used for local development because actually changing the defaults on the deployed
 	// operator requires additional yaml changes. But we need a local development override because
 	// mcafee takes the default HealthPortProbe on Mac.
-	Port                    int
-	MetricsPort             int
-	HealthProbePort         int
+	Port            int
+	MetricsPort     int
+	HealthProbePort int
+	// WaitDurationForResource is how long a Deployment or StatefulSet may take to roll out before it is reported as failed
 	WaitDurationForResource time.Duration
 	ErrorTimeout            time.Duration
-	FeatureFlags            featureFlags
+	// UnregisterTimeout is how long after deletion the operator keeps trying to unmanage the cluster in Astra
+	// before removing the finalizer anyway
+	UnregisterTimeout time.Duration
+	FeatureFlags      featureFlags
+	// KubernetesVersions are the kubernetes versions allowed by the k8s-version pre-check
+	KubernetesVersions kubernetesVersions
 }
 
 // DefaultConfiguration Returns a MutableConfiguration that holds all the default values to be used,
@@ -77,9 +86,20 @@ func DefaultConfiguration() *MutableConfiguration {
 		HealthProbePort:         8081,
 		WaitDurationForResource: 5 * time.Minute,
 		ErrorTimeout:            5,
+		UnregisterTimeout:       5 * time.Minute,
 		FeatureFlags: featureFlags{
 			DeployNatsConnector: true,
 			DeployNeptune:       true,
+			EnableWebhooks:      false,
+			ServerSideApply:     true,
+		},
+		KubernetesVersions: kubernetesVersions{
+			Default: KubernetesVersionWindow{
+				Min:       "1.24",
+				MaxTested: "1.29",
+				Max:       "1.31",
+			},
+			Flavors: map[string]KubernetesVersionWindow{},
 		},
 	}
 }
@@ -94,9 +114,16 @@ func toImmutableConfig(config *MutableConfiguration) *ImmutableConfiguration {
 		healthProbePort:         config.HealthProbePort,
 		waitDurationForResource: config.WaitDurationForResource,
 		errorTimeout:            config.ErrorTimeout,
+		unregisterTimeout:       config.UnregisterTimeout,
 		featureFlags: ImmutableFeatureFlags{
 			deployNatsConnector: config.FeatureFlags.DeployNatsConnector,
 			deployNeptune:       config.FeatureFlags.DeployNeptune,
+			enableWebhooks:      config.FeatureFlags.EnableWebhooks,
+			serverSideApply:     config.FeatureFlags.ServerSideApply,
+		},
+		kubernetesVersions: ImmutableKubernetesVersions{
+			defaultWindow: config.KubernetesVersions.Default,
+			flavors:       maps.Clone(config.KubernetesVersions.Flavors),
 		},
 		config: config,
 	}
@@ -137,18 +164,33 @@ func (i ImmutableConfiguration) ErrorTimeout() time.Duration {
 	return i.errorTimeout
 }
 
+func (i ImmutableConfiguration) UnregisterTimeout() time.Duration {
+	return i.unregisterTimeout
+}
+
 func (i ImmutableConfiguration) FeatureFlags() ImmutableFeatureFlags {
 	return i.featureFlags
 }
 
+func (i ImmutableConfiguration) KubernetesVersions() ImmutableKubernetesVersions {
+	return i.kubernetesVersions
+}
+
 type ImmutableFeatureFlags struct {
 	deployNatsConnector bool
 	deployNeptune       bool
+	enableWebhooks      bool
+	serverSideApply     bool
 }
 
 type featureFlags struct {
 	DeployNatsConnector bool
 	DeployNeptune       bool
+	// EnableWebhooks registers the AstraConnector admission webhooks, the webhook serving certs must be mounted
+	EnableWebhooks bool
+	// ServerSideApply server-side applies the resources of the Deployers that support it, e.g. Neptune.
+	// When disabled their MutateFns are used with CreateOrUpdate, as for the other Deployers.
+	ServerSideApply bool
 }
 
 func (f ImmutableFeatureFlags) DeployNatsConnector() bool {
@@ -159,6 +201,61 @@ func (f ImmutableFeatureFlags) DeployNeptune() bool {
 	return f.deployNeptune
 }
 
+func (f ImmutableFeatureFlags) EnableWebhooks() bool {
+	return f.enableWebhooks
+}
+
+func (f ImmutableFeatureFlags) ServerSideApply() bool {
+	return f.serverSideApply
+}
+
+// KubernetesVersionWindow is a range of kubernetes minor versions, e.g. 1.24 to 1.29
+type KubernetesVersionWindow struct {
+	// Min is the oldest supported version
+	Min string
+	// MaxTested is the newest tested version, newer versions up to Max are allowed with a warning
+	MaxTested string
+	// Max is the newest allowed version, versions newer than MaxTested up to Max only get a warning.
+	// Set it to MaxTested to fail every untested version.
+	Max string
+}
+
+// Merge Returns the window with the versions set in override replaced
+func (w KubernetesVersionWindow) Merge(override KubernetesVersionWindow) KubernetesVersionWindow {
+	if override.Min != "" {
+		w.Min = override.Min
+	}
+	if override.MaxTested != "" {
+		w.MaxTested = override.MaxTested
+	}
+	if override.Max != "" {
+		w.Max = override.Max
+	}
+	return w
+}
+
+type ImmutableKubernetesVersions struct {
+	defaultWindow KubernetesVersionWindow
+	flavors       map[string]KubernetesVersionWindow
+}
+
+type kubernetesVersions struct {
+	Default KubernetesVersionWindow
+	// Flavors overrides the window of a cluster flavor detected by the ClusterTypeChecker, e.g. openshift.
+	// The versions a flavor leaves empty are taken from Default
+	Flavors map[string]KubernetesVersionWindow
+}
+
+// Window Returns the window of kubernetes versions of the given cluster flavor
+func (k ImmutableKubernetesVersions) Window(flavor string) KubernetesVersionWindow {
+	return k.defaultWindow.Merge(k.flavors[flavor])
+}
+
+// HasFlavors Returns true if the window is overridden for any cluster flavor
+func (k ImmutableKubernetesVersions) HasFlavors() bool {
+	return len(k.flavors) > 0
+}
+
 // Viper configuration
 func init() {
 	Config = toImmutableConfig(load())
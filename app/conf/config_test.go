@@ -48,5 +48,36 @@ func TestImmutableFeatureFlags(t *testing.T) {
 		t.Errorf("Expected false, got %v", flags.DeployNeptune())
 	}
 
+	if flags.EnableWebhooks() != false {
+		t.Errorf("Expected false, got %v", flags.EnableWebhooks())
+	}
+
+	if flags.ServerSideApply() != false {
+		t.Errorf("Expected false, got %v", flags.ServerSideApply())
+	}
+
+	if conf.DefaultConfiguration().FeatureFlags.ServerSideApply != true {
+		t.Errorf("Expected server-side apply to be enabled by default")
+	}
+
 	// TODO add test
 }
+
+func TestKubernetesVersionWindow(t *testing.T) {
+	defaultWindow := conf.KubernetesVersionWindow{Min: "1.24", MaxTested: "1.29", Max: "1.31"}
+
+	t.Run("Merge__Override", func(t *testing.T) {
+		window := defaultWindow.Merge(conf.KubernetesVersionWindow{Min: "1.25", Max: "1.30"})
+		assert.Equal(t, conf.KubernetesVersionWindow{Min: "1.25", MaxTested: "1.29", Max: "1.30"}, window)
+	})
+
+	t.Run("Merge__EmptyOverride", func(t *testing.T) {
+		assert.Equal(t, defaultWindow, defaultWindow.Merge(conf.KubernetesVersionWindow{}))
+	})
+
+	t.Run("Window__Default", func(t *testing.T) {
+		kubernetesVersions := conf.Config.KubernetesVersions()
+		assert.False(t, kubernetesVersions.HasFlavors())
+		assert.Equal(t, defaultWindow, kubernetesVersions.Window("openshift"))
+	})
+}
@@ -10,12 +10,11 @@ import (
 	"maps"
 	"strconv"
 
-	"k8s.io/apimachinery/pkg/api/resource"
-
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
@@ -37,22 +36,7 @@ func (d *AstraConnectDeployer) GetDeploymentObjects(m *v1.AstraConnector, ctx co
 	log := ctrllog.FromContext(ctx)
 	ls := LabelsForAstraConnectClient(common.AstraConnectName, m.Spec.Labels)
 
-	var imageRegistry string
-	var containerImage string
-	var connectorImage string
-	if m.Spec.ImageRegistry.Name != "" {
-		imageRegistry = m.Spec.ImageRegistry.Name
-	} else {
-		imageRegistry = common.DefaultImageRegistry
-	}
-
-	if m.Spec.AstraConnect.Image != "" {
-		containerImage = m.Spec.AstraConnect.Image
-	} else {
-		containerImage = common.ConnectorImageTag
-	}
-
-	connectorImage = fmt.Sprintf("%s/astra-connector:%s", imageRegistry, containerImage)
+	connectorImage := m.GetConnectorImage()
 	log.Info("Using AstraConnector image", "image", connectorImage)
 
 	if m.Spec.Astra.ClusterId == "" && m.Spec.Astra.ClusterName == "" {
@@ -61,6 +45,9 @@ func (d *AstraConnectDeployer) GetDeploymentObjects(m *v1.AstraConnector, ctx co
 		return nil, nil, err
 	}
 
+	scheduling := corev1.PodSpec{}
+	m.Spec.AstraConnect.Scheduling.ApplyTo(&scheduling)
+
 	dep := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      common.AstraConnectName,
@@ -130,16 +117,31 @@ func (d *AstraConnectDeployer) GetDeploymentObjects(m *v1.AstraConnector, ctx co
 								},
 							},
 						},
-						Resources: getConnectorResourceLimit(m.Spec.AstraConnect.ResourceRequirements.Limits,
-							m.Spec.AstraConnect.ResourceRequirements.Requests),
-						SecurityContext: conf.GetSecurityContext(),
+						Resources: common.ResolveResourceRequirements(m.Spec.AstraConnect.ResourceRequirements,
+							common.GetDefaultConnectorResources()),
+						SecurityContext: model.RestrictedSecurityContext(m, conf.GetSecurityContext()),
 					}},
-					ServiceAccountName: common.AstraConnectName,
+					SecurityContext:           model.RestrictedPodSecurityContext(m, nil),
+					ServiceAccountName:        common.AstraConnectName,
+					NodeSelector:              scheduling.NodeSelector,
+					Tolerations:               scheduling.Tolerations,
+					Affinity:                  scheduling.Affinity,
+					TopologySpreadConstraints: scheduling.TopologySpreadConstraints,
+					PriorityClassName:         scheduling.PriorityClassName,
 				},
 			},
 		},
 	}
 
+	// Route the traffic of astraconnect to Astra through the proxy
+	dep.Spec.Template.Spec.Containers[0].Env = append(dep.Spec.Template.Spec.Containers[0].Env, m.Spec.Proxy.GetEnvVars()...)
+	// Tell astraconnect the flavor and versions of the cluster detected by the controller
+	dep.Spec.Template.Spec.Containers[0].Env = append(dep.Spec.Template.Spec.Containers[0].Env, m.Status.Cluster.GetEnvVars()...)
+
+	if m.Spec.Astra.CABundleRef != nil {
+		addCABundle(&dep.Spec.Template.Spec, m.Spec.Astra.CABundleRef)
+	}
+
 	if m.Spec.ImageRegistry.Secret != "" {
 		dep.Spec.Template.Spec.ImagePullSecrets = []corev1.LocalObjectReference{
 			{
@@ -148,47 +150,56 @@ func (d *AstraConnectDeployer) GetDeploymentObjects(m *v1.AstraConnector, ctx co
 		}
 	}
 
+	// dep is overwritten with the Deployment in the cluster before mutateFunc runs, so keep what we want
+	desired := dep.DeepCopy()
 	mutateFunc := func() error {
-		// Get the containers
-		containers := dep.Spec.Template.Spec.Containers
-		newContainers := make([]corev1.Container, 0, 1)
-
-		for _, container := range containers {
-			if container.Name == common.AstraConnectName {
-				container.Image = connectorImage
-			}
-			newContainers = append(newContainers, container)
-		}
-
-		// Update the containers in the deployment
-		dep.Spec.Template.Spec.Containers = newContainers
+		model.MutateDeployment(dep, desired)
 		return nil
 	}
 
 	return []client.Object{dep}, mutateFunc, nil
 }
 
-func getConnectorResourceLimit(limit, request corev1.ResourceList) corev1.ResourceRequirements {
-	var connectResourceSize corev1.ResourceRequirements
-	if limit != nil {
-		connectResourceSize.Limits = limit
-	} else {
-		// not set let's set default
-		connectResourceSize.Limits = corev1.ResourceList{
-			corev1.ResourceMemory: resource.MustParse("1Gi"),
+// addCABundle mounts the CA bundle referenced by spec.astra.caBundleRef into the astraconnect container
+// and exposes its path in the ASTRA_CA_BUNDLE_PATH env
+func addCABundle(podSpec *corev1.PodSpec, caBundleRef *v1.CABundleRef) {
+	items := []corev1.KeyToPath{{Path: common.CABundleFileName}}
+	volume := corev1.Volume{Name: common.CABundleVolumeName}
+	if caBundleRef.ConfigMapKeyRef != nil {
+		items[0].Key = caBundleRef.ConfigMapKeyRef.Key
+		volume.VolumeSource = corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: caBundleRef.ConfigMapKeyRef.Name},
+				Items:                items,
+			},
+		}
+	} else if caBundleRef.SecretKeyRef != nil {
+		items[0].Key = caBundleRef.SecretKeyRef.Key
+		volume.VolumeSource = corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: caBundleRef.SecretKeyRef.Name,
+				Items:      items,
+			},
 		}
+	} else {
+		return
 	}
+	podSpec.Volumes = append(podSpec.Volumes, volume)
 
-	if request != nil {
-		connectResourceSize.Requests = request
-	} else {
-		// not set let's set default
-		connectResourceSize.Requests = corev1.ResourceList{
-			corev1.ResourceCPU:    resource.MustParse("0.1"),
-			corev1.ResourceMemory: resource.MustParse("1Gi"),
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Name != common.AstraConnectName {
+			continue
 		}
+		podSpec.Containers[i].VolumeMounts = append(podSpec.Containers[i].VolumeMounts, corev1.VolumeMount{
+			Name:      common.CABundleVolumeName,
+			MountPath: common.CABundleMountPath,
+			ReadOnly:  true,
+		})
+		podSpec.Containers[i].Env = append(podSpec.Containers[i].Env, corev1.EnvVar{
+			Name:  "ASTRA_CA_BUNDLE_PATH",
+			Value: common.CABundleMountPath + "/" + common.CABundleFileName,
+		})
 	}
-	return connectResourceSize
 }
 
 // GetServiceObjects returns an Astra-Connect Service object
@@ -208,7 +219,13 @@ func (d *AstraConnectDeployer) GetConfigMapObjects(m *v1.AstraConnector, ctx con
 			"skip_tls_validation": strconv.FormatBool(m.Spec.Astra.SkipTLSValidation),
 		},
 	}
-	return []client.Object{configMap}, model.NonMutateFn, nil
+	data := configMap.Data
+
+	mutateFn := func() error {
+		configMap.Data = data
+		return nil
+	}
+	return []client.Object{configMap}, mutateFn, nil
 }
 
 // GetServiceAccountObjects returns a ServiceAccount object for Astra Connect
@@ -292,11 +309,15 @@ func (d *AstraConnectDeployer) GetClusterRoleObjects(m *v1.AstraConnector, ctx c
 			},
 			Verbs: []string{"watch", "list", "get"},
 		},
-		{
-			APIGroups: []string{"security.openshift.io"},
-			Resources: []string{"securitycontextconstraints"},
-			Verbs:     []string{"use"},
-		},
+	}
+	// On OpenShift astraconnect may only use its own SCC, elsewhere there is no SCC to use
+	if model.IsOpenShift(m) {
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups:     []string{"security.openshift.io"},
+			Resources:     []string{"securitycontextconstraints"},
+			ResourceNames: []string{common.AstraConnectName},
+			Verbs:         []string{"use"},
+		})
 	}
 
 	clusterRole := &rbacv1.ClusterRole{
@@ -314,6 +335,49 @@ func (d *AstraConnectDeployer) GetClusterRoleObjects(m *v1.AstraConnector, ctx c
 	return []client.Object{clusterRole}, mutateFn, nil
 }
 
+// GetSecurityContextConstraintsObjects returns the SecurityContextConstraints of Astra Connect on OpenShift, it is granted
+// to the astraconnect ServiceAccount by the ClusterRole. It matches restricted-v2, so the UIDs come from the namespace range.
+func (d *AstraConnectDeployer) GetSecurityContextConstraintsObjects(m *v1.AstraConnector, ctx context.Context) ([]client.Object, controllerutil.MutateFn, error) {
+	if !model.IsOpenShift(m) {
+		return nil, nil, nil
+	}
+
+	scc := &unstructured.Unstructured{Object: map[string]interface{}{
+		"allowHostDirVolumePlugin": false,
+		"allowHostIPC":             false,
+		"allowHostNetwork":         false,
+		"allowHostPID":             false,
+		"allowHostPorts":           false,
+		"allowPrivilegeEscalation": false,
+		"allowPrivilegedContainer": false,
+		"readOnlyRootFilesystem":   false,
+		"requiredDropCapabilities": []interface{}{"ALL"},
+		"fsGroup":                  map[string]interface{}{"type": "MustRunAs"},
+		"runAsUser":                map[string]interface{}{"type": "MustRunAsRange"},
+		"seLinuxContext":           map[string]interface{}{"type": "MustRunAs"},
+		"supplementalGroups":       map[string]interface{}{"type": "RunAsAny"},
+		"seccompProfiles":          []interface{}{"runtime/default"},
+		"volumes":                  []interface{}{"configMap", "downwardAPI", "emptyDir", "ephemeral", "persistentVolumeClaim", "projected", "secret"},
+		"users":                    []interface{}{},
+		"groups":                   []interface{}{},
+	}}
+	scc.SetGroupVersionKind(model.SecurityContextConstraintsGVK)
+	scc.SetName(common.AstraConnectName)
+
+	// scc is overwritten with the SCC in the cluster before mutateFunc runs, so keep what we want
+	desired := scc.DeepCopy()
+	mutateFunc := func() error {
+		for key, value := range desired.Object {
+			if key != "metadata" {
+				scc.Object[key] = value
+			}
+		}
+		return nil
+	}
+
+	return []client.Object{scc}, mutateFunc, nil
+}
+
 func (d *AstraConnectDeployer) GetClusterRoleBindingObjects(m *v1.AstraConnector, ctx context.Context) ([]client.Object, controllerutil.MutateFn, error) {
 	clusterRoleBinding := &rbacv1.ClusterRoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
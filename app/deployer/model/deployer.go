@@ -6,9 +6,12 @@ package model
 
 import (
 	"context"
+
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	"github.com/NetApp-Polaris/astra-connector-operator/app/conf"
 	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
 )
 
@@ -20,10 +23,24 @@ type Deployer interface {
 	GetServiceAccountObjects(m *v1.AstraConnector, ctx context.Context) ([]client.Object, controllerutil.MutateFn, error)
 	GetRoleObjects(m *v1.AstraConnector, ctx context.Context) ([]client.Object, controllerutil.MutateFn, error)
 	GetClusterRoleObjects(m *v1.AstraConnector, ctx context.Context) ([]client.Object, controllerutil.MutateFn, error)
+	GetSecurityContextConstraintsObjects(m *v1.AstraConnector, ctx context.Context) ([]client.Object, controllerutil.MutateFn, error)
 	GetRoleBindingObjects(m *v1.AstraConnector, ctx context.Context) ([]client.Object, controllerutil.MutateFn, error)
 	GetClusterRoleBindingObjects(m *v1.AstraConnector, ctx context.Context) ([]client.Object, controllerutil.MutateFn, error)
 }
 
+// ServerSideApplyDeployer is implemented by Deployers that select server-side apply for their objects,
+// the objects are applied as returned and their MutateFns are not used
+type ServerSideApplyDeployer interface {
+	UseServerSideApply() bool
+}
+
+// UsesServerSideApply Returns true if the objects of the deployer are server-side applied,
+// Deployers default to CreateOrUpdate with their MutateFns, as do all of them if the ServerSideApply feature flag is off
+func UsesServerSideApply(d Deployer) bool {
+	ssaDeployer, ok := d.(ServerSideApplyDeployer)
+	return ok && ssaDeployer.UseServerSideApply() && conf.Config.FeatureFlags().ServerSideApply()
+}
+
 // Define the MutateFn function
 func NonMutateFn() error {
 	// TODO https://jira.ngage.netapp.com/browse/ASTRACTL-27555
@@ -32,3 +49,13 @@ func NonMutateFn() error {
 	// want to remove duplicated code like each deployer setting image and secret can be do once here
 	return nil
 }
+
+// SetPodScheduling Replaces the scheduling fields of the pod spec with the desired ones,
+// used by the MutateFns so scheduling changes in the AstraConnector spec reach existing workloads
+func SetPodScheduling(podSpec *corev1.PodSpec, desired corev1.PodSpec) {
+	podSpec.NodeSelector = desired.NodeSelector
+	podSpec.Tolerations = desired.Tolerations
+	podSpec.Affinity = desired.Affinity
+	podSpec.TopologySpreadConstraints = desired.TopologySpreadConstraints
+	podSpec.PriorityClassName = desired.PriorityClassName
+}
@@ -1,7 +1,9 @@
 package model_test
 
 import (
+	"github.com/NetApp-Polaris/astra-connector-operator/app/deployer/connector"
 	"github.com/NetApp-Polaris/astra-connector-operator/app/deployer/model"
+	"github.com/NetApp-Polaris/astra-connector-operator/app/deployer/neptune"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -10,3 +12,8 @@ func TestMutateFunc(t *testing.T) {
 	mutFunc := model.NonMutateFn()
 	assert.Nil(t, mutFunc)
 }
+
+func TestUsesServerSideApply(t *testing.T) {
+	assert.False(t, model.UsesServerSideApply(connector.NewAstraConnectorDeployer()))
+	assert.True(t, model.UsesServerSideApply(neptune.NewNeptuneClientDeployerV2()))
+}
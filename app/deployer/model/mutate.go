@@ -0,0 +1,234 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package model
+
+import (
+	"encoding/json"
+	"maps"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ManagedMetadataAnnotation records the label and annotation keys set by the operator, so the ones it no longer sets
+// are removed while the ones added by others are kept
+const ManagedMetadataAnnotation = "astra.netapp.io/managed-metadata"
+
+// managedMetadata is the value of ManagedMetadataAnnotation
+type managedMetadata struct {
+	Labels              []string `json:"labels,omitempty"`
+	Annotations         []string `json:"annotations,omitempty"`
+	TemplateLabels      []string `json:"templateLabels,omitempty"`
+	TemplateAnnotations []string `json:"templateAnnotations,omitempty"`
+}
+
+// MutateDeployment Converges the Deployment read from the cluster to the desired one built by a deployer.
+// The desired pod template is applied as a whole, while labels and annotations added by others
+// (e.g. kubectl rollout restart) and fields defaulted by the API server are kept.
+func MutateDeployment(existing, desired *appsv1.Deployment) {
+	previous := getManagedMetadata(existing.Annotations)
+	existing.Labels = mergeStringMaps(existing.Labels, desired.Labels, previous.Labels, nil)
+	existing.Annotations = mergeStringMaps(existing.Annotations, desired.Annotations, previous.Annotations, nil)
+
+	existing.Spec.Replicas = desired.Spec.Replicas
+	// The selector is immutable, the template labels are merged so the existing selector keeps matching
+	if existing.Spec.Selector == nil {
+		existing.Spec.Selector = desired.Spec.Selector
+	}
+	// The parameters of the strategy are defaulted by the API server, only a change of type is applied
+	if desired.Spec.Strategy.Type != "" && existing.Spec.Strategy.Type != desired.Spec.Strategy.Type {
+		existing.Spec.Strategy = desired.Spec.Strategy
+	}
+
+	var selectorLabels map[string]string
+	if existing.Spec.Selector != nil {
+		selectorLabels = existing.Spec.Selector.MatchLabels
+	}
+	existing.Spec.Template.Labels = mergeStringMaps(existing.Spec.Template.Labels, desired.Spec.Template.Labels,
+		previous.TemplateLabels, selectorLabels)
+	existing.Spec.Template.Annotations = mergeStringMaps(existing.Spec.Template.Annotations, desired.Spec.Template.Annotations,
+		previous.TemplateAnnotations, nil)
+	MutatePodSpec(&existing.Spec.Template.Spec, desired.Spec.Template.Spec)
+
+	setManagedMetadata(existing, managedMetadata{
+		Labels:              sortedKeys(desired.Labels),
+		Annotations:         sortedKeys(desired.Annotations),
+		TemplateLabels:      sortedKeys(desired.Spec.Template.Labels),
+		TemplateAnnotations: sortedKeys(desired.Spec.Template.Annotations),
+	})
+}
+
+// getManagedMetadata Returns the keys recorded in ManagedMetadataAnnotation, none if it is missing or invalid
+func getManagedMetadata(annotations map[string]string) managedMetadata {
+	var managed managedMetadata
+	if value, ok := annotations[ManagedMetadataAnnotation]; ok {
+		_ = json.Unmarshal([]byte(value), &managed)
+	}
+	return managed
+}
+
+func setManagedMetadata(deployment *appsv1.Deployment, managed managedMetadata) {
+	value, err := json.Marshal(managed)
+	if err != nil {
+		return
+	}
+	if deployment.Annotations == nil {
+		deployment.Annotations = map[string]string{}
+	}
+	deployment.Annotations[ManagedMetadataAnnotation] = string(value)
+}
+
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// MutatePodSpec Sets the containers, volumes and pod settings of the desired pod spec on the existing one
+func MutatePodSpec(existing *corev1.PodSpec, desired corev1.PodSpec) {
+	existing.InitContainers = mutateContainers(existing.InitContainers, desired.InitContainers)
+	existing.Containers = mutateContainers(existing.Containers, desired.Containers)
+	existing.Volumes = mutateVolumes(existing.Volumes, desired.Volumes)
+
+	existing.ServiceAccountName = desired.ServiceAccountName
+	existing.DeprecatedServiceAccount = desired.ServiceAccountName
+	existing.ImagePullSecrets = desired.ImagePullSecrets
+
+	if desired.SecurityContext != nil {
+		existing.SecurityContext = desired.SecurityContext
+	}
+	if desired.TerminationGracePeriodSeconds != nil {
+		existing.TerminationGracePeriodSeconds = desired.TerminationGracePeriodSeconds
+	}
+
+	SetPodScheduling(existing, desired)
+}
+
+// mutateContainers Returns the desired containers, keeping the API server defaults of the existing container of the same name
+func mutateContainers(existing, desired []corev1.Container) []corev1.Container {
+	var containers []corev1.Container
+	for _, desiredContainer := range desired {
+		container := desiredContainer.DeepCopy()
+		for i := range existing {
+			if existing[i].Name == container.Name {
+				keepContainerDefaults(container, &existing[i])
+				break
+			}
+		}
+		containers = append(containers, *container)
+	}
+	return containers
+}
+
+func keepContainerDefaults(container, existing *corev1.Container) {
+	if container.ImagePullPolicy == "" {
+		container.ImagePullPolicy = existing.ImagePullPolicy
+	}
+	if container.TerminationMessagePath == "" {
+		container.TerminationMessagePath = existing.TerminationMessagePath
+	}
+	if container.TerminationMessagePolicy == "" {
+		container.TerminationMessagePolicy = existing.TerminationMessagePolicy
+	}
+
+	for i := range container.Ports {
+		for _, existingPort := range existing.Ports {
+			if container.Ports[i].Protocol == "" && container.Ports[i].ContainerPort == existingPort.ContainerPort {
+				container.Ports[i].Protocol = existingPort.Protocol
+			}
+		}
+	}
+
+	for i := range container.Env {
+		fieldRef := getFieldRef(container.Env[i])
+		if fieldRef == nil || fieldRef.APIVersion != "" {
+			continue
+		}
+		for _, existingEnv := range existing.Env {
+			if existingFieldRef := getFieldRef(existingEnv); existingEnv.Name == container.Env[i].Name && existingFieldRef != nil {
+				fieldRef.APIVersion = existingFieldRef.APIVersion
+			}
+		}
+	}
+
+	keepProbeDefaults(container.LivenessProbe, existing.LivenessProbe)
+	keepProbeDefaults(container.ReadinessProbe, existing.ReadinessProbe)
+	keepProbeDefaults(container.StartupProbe, existing.StartupProbe)
+}
+
+func getFieldRef(env corev1.EnvVar) *corev1.ObjectFieldSelector {
+	if env.ValueFrom == nil {
+		return nil
+	}
+	return env.ValueFrom.FieldRef
+}
+
+func keepProbeDefaults(probe, existing *corev1.Probe) {
+	if probe == nil || existing == nil {
+		return
+	}
+	if probe.TimeoutSeconds == 0 {
+		probe.TimeoutSeconds = existing.TimeoutSeconds
+	}
+	if probe.PeriodSeconds == 0 {
+		probe.PeriodSeconds = existing.PeriodSeconds
+	}
+	if probe.SuccessThreshold == 0 {
+		probe.SuccessThreshold = existing.SuccessThreshold
+	}
+	if probe.FailureThreshold == 0 {
+		probe.FailureThreshold = existing.FailureThreshold
+	}
+	if probe.HTTPGet != nil && existing.HTTPGet != nil && probe.HTTPGet.Scheme == "" {
+		probe.HTTPGet.Scheme = existing.HTTPGet.Scheme
+	}
+}
+
+// mutateVolumes Returns the desired volumes, keeping the defaulted file mode of the existing volume of the same name
+func mutateVolumes(existing, desired []corev1.Volume) []corev1.Volume {
+	var volumes []corev1.Volume
+	for _, desiredVolume := range desired {
+		volume := desiredVolume.DeepCopy()
+		for _, existingVolume := range existing {
+			if existingVolume.Name != volume.Name {
+				continue
+			}
+			if volume.ConfigMap != nil && existingVolume.ConfigMap != nil && volume.ConfigMap.DefaultMode == nil {
+				volume.ConfigMap.DefaultMode = existingVolume.ConfigMap.DefaultMode
+			}
+			if volume.Secret != nil && existingVolume.Secret != nil && volume.Secret.DefaultMode == nil {
+				volume.Secret.DefaultMode = existingVolume.Secret.DefaultMode
+			}
+		}
+		volumes = append(volumes, *volume)
+	}
+	return volumes
+}
+
+// mergeStringMaps Returns existing with the desired entries set. Entries previously set by the operator and no longer
+// desired are removed, unless they are kept, entries added by others are left alone.
+func mergeStringMaps(existing, desired map[string]string, previous []string, keep map[string]string) map[string]string {
+	for _, key := range previous {
+		if _, ok := desired[key]; ok {
+			continue
+		}
+		if _, ok := keep[key]; ok {
+			continue
+		}
+		delete(existing, key)
+	}
+	if len(desired) == 0 {
+		return existing
+	}
+	if existing == nil {
+		existing = map[string]string{}
+	}
+	maps.Copy(existing, desired)
+	return existing
+}
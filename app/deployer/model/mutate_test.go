@@ -0,0 +1,175 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/NetApp-Polaris/astra-connector-operator/app/deployer/model"
+)
+
+func newDesiredDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "test",
+			Labels: map[string]string{"app": "test", "team": "new"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32(2),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test", "team": "new"}},
+			Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test", "team": "new"}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "test",
+						Image: "registry/test:2",
+						Env:   []corev1.EnvVar{{Name: "CLUSTER_ID", Value: "new"}},
+						Ports: []corev1.ContainerPort{{ContainerPort: 8443}},
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/readyz"}},
+						},
+					}},
+					ServiceAccountName: "test",
+					ImagePullSecrets:   []corev1.LocalObjectReference{{Name: "pull-secret"}},
+				},
+			},
+		},
+	}
+}
+
+func TestMutateDeployment(t *testing.T) {
+	t.Run("MutateDeployment__DesiredSpecIsApplied", func(t *testing.T) {
+		desired := newDesiredDeployment()
+		existing := &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Replicas: pointer.Int32(1),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: "test", Image: "registry/test:1", Env: []corev1.EnvVar{{Name: "CLUSTER_ID", Value: "old"}}},
+							{Name: "removed", Image: "registry/removed:1"},
+						},
+						ServiceAccountName: "old",
+					},
+				},
+			},
+		}
+
+		model.MutateDeployment(existing, desired)
+
+		assert.Equal(t, int32(2), *existing.Spec.Replicas)
+		assert.Equal(t, appsv1.RecreateDeploymentStrategyType, existing.Spec.Strategy.Type)
+		assert.Equal(t, desired.Spec.Template.Spec.Containers, existing.Spec.Template.Spec.Containers)
+		assert.Equal(t, "test", existing.Spec.Template.Spec.ServiceAccountName)
+		assert.Equal(t, desired.Spec.Template.Spec.ImagePullSecrets, existing.Spec.Template.Spec.ImagePullSecrets)
+		assert.Equal(t, desired.Labels, existing.Labels)
+	})
+
+	t.Run("MutateDeployment__FieldsOfOthersArePreserved", func(t *testing.T) {
+		desired := newDesiredDeployment()
+		existing := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{"app": "test", "team": "old"},
+				Annotations: map[string]string{"deployment.kubernetes.io/revision": "3"},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test", "team": "old"}},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels:      map[string]string{"app": "test", "team": "old"},
+						Annotations: map[string]string{"kubectl.kubernetes.io/restartedAt": "2024-01-01T00:00:00Z"},
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{
+							Name:                     "test",
+							ImagePullPolicy:          corev1.PullIfNotPresent,
+							TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+							TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+							Ports:                    []corev1.ContainerPort{{ContainerPort: 8443, Protocol: corev1.ProtocolTCP}},
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler:     corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/readyz", Scheme: corev1.URISchemeHTTP}},
+								TimeoutSeconds:   1,
+								PeriodSeconds:    10,
+								SuccessThreshold: 1,
+								FailureThreshold: 3,
+							},
+						}},
+						DNSPolicy:     corev1.DNSClusterFirst,
+						RestartPolicy: corev1.RestartPolicyAlways,
+						SchedulerName: corev1.DefaultSchedulerName,
+					},
+				},
+			},
+		}
+
+		model.MutateDeployment(existing, desired)
+
+		// The selector is immutable and still matches the pod labels
+		assert.Equal(t, map[string]string{"app": "test", "team": "old"}, existing.Spec.Selector.MatchLabels)
+		assert.Equal(t, "new", existing.Spec.Template.Labels["team"])
+		assert.Equal(t, "3", existing.Annotations["deployment.kubernetes.io/revision"])
+		assert.Equal(t, "2024-01-01T00:00:00Z", existing.Spec.Template.Annotations["kubectl.kubernetes.io/restartedAt"])
+
+		podSpec := existing.Spec.Template.Spec
+		assert.Equal(t, corev1.DNSClusterFirst, podSpec.DNSPolicy)
+		assert.Equal(t, corev1.RestartPolicyAlways, podSpec.RestartPolicy)
+		assert.Equal(t, corev1.DefaultSchedulerName, podSpec.SchedulerName)
+
+		container := podSpec.Containers[0]
+		assert.Equal(t, "registry/test:2", container.Image)
+		assert.Equal(t, corev1.PullIfNotPresent, container.ImagePullPolicy)
+		assert.Equal(t, corev1.TerminationMessagePathDefault, container.TerminationMessagePath)
+		assert.Equal(t, corev1.ProtocolTCP, container.Ports[0].Protocol)
+		assert.Equal(t, int32(1), container.ReadinessProbe.TimeoutSeconds)
+		assert.Equal(t, int32(3), container.ReadinessProbe.FailureThreshold)
+		assert.Equal(t, corev1.URISchemeHTTP, container.ReadinessProbe.HTTPGet.Scheme)
+
+		// The desired deployment is left untouched, so it can be applied again
+		assert.Equal(t, newDesiredDeployment(), desired)
+	})
+
+	t.Run("MutateDeployment__RemovedLabelsAndAnnotationsAreRemoved", func(t *testing.T) {
+		desired := newDesiredDeployment()
+		desired.Annotations = map[string]string{"owner": "astra"}
+		desired.Spec.Template.Annotations = map[string]string{"config-hash": "1"}
+		existing := &appsv1.Deployment{}
+		model.MutateDeployment(existing, desired)
+
+		// Added by others after the first apply
+		existing.Labels["other"] = "label"
+		existing.Annotations["deployment.kubernetes.io/revision"] = "2"
+		existing.Spec.Template.Annotations["kubectl.kubernetes.io/restartedAt"] = "2024-01-01T00:00:00Z"
+
+		desired = newDesiredDeployment()
+		delete(desired.Labels, "team")
+		delete(desired.Spec.Template.Labels, "team")
+		model.MutateDeployment(existing, desired)
+
+		assert.Equal(t, map[string]string{"app": "test", "other": "label"}, existing.Labels)
+		assert.NotContains(t, existing.Annotations, "owner")
+		assert.Equal(t, "2", existing.Annotations["deployment.kubernetes.io/revision"])
+		assert.Equal(t, `{"labels":["app"],"templateLabels":["app"]}`, existing.Annotations[model.ManagedMetadataAnnotation])
+		assert.Equal(t, map[string]string{"kubectl.kubernetes.io/restartedAt": "2024-01-01T00:00:00Z"}, existing.Spec.Template.Annotations)
+		// The selector is immutable, so the pod labels it matches are kept
+		assert.Equal(t, map[string]string{"app": "test", "team": "new"}, existing.Spec.Template.Labels)
+	})
+
+	t.Run("MutateDeployment__RemovedVolumeIsRemoved", func(t *testing.T) {
+		desired := newDesiredDeployment()
+		existing := newDesiredDeployment()
+		existing.Spec.Template.Spec.Volumes = []corev1.Volume{{Name: "ca-bundle"}}
+
+		model.MutateDeployment(existing, desired)
+
+		assert.Nil(t, existing.Spec.Template.Spec.Volumes)
+	})
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package model
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+
+	"github.com/NetApp-Polaris/astra-connector-operator/details/k8s"
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+)
+
+// SecurityContextConstraintsGVK is the kind of the OpenShift SecurityContextConstraints
+var SecurityContextConstraintsGVK = schema.GroupVersionKind{
+	Group:   "security.openshift.io",
+	Version: "v1",
+	Kind:    "SecurityContextConstraints",
+}
+
+// IsOpenShift Returns true if the cluster of the AstraConnector was detected as OpenShift or a flavor based on it
+func IsOpenShift(m *v1.AstraConnector) bool {
+	return m.Status.Cluster != nil && k8s.IsOpenShiftFlavor(m.Status.Cluster.Flavor)
+}
+
+// RestrictedSecurityContext Returns a copy of the container security context that meets the restricted Pod Security
+// Standard. On OpenShift the user and group are left for the SCC to assign from the range of the namespace.
+func RestrictedSecurityContext(m *v1.AstraConnector, securityContext *corev1.SecurityContext) *corev1.SecurityContext {
+	restricted := securityContext.DeepCopy()
+	if restricted == nil {
+		restricted = &corev1.SecurityContext{}
+	}
+	restricted.AllowPrivilegeEscalation = pointer.Bool(false)
+	restricted.Capabilities = &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}}
+
+	if IsOpenShift(m) {
+		restricted.RunAsUser = nil
+		restricted.RunAsGroup = nil
+	}
+	return restricted
+}
+
+// RestrictedPodSecurityContext Returns a copy of the pod security context that meets the restricted Pod Security
+// Standard. On OpenShift the user, group and fs group are left for the SCC to assign from the range of the namespace.
+func RestrictedPodSecurityContext(m *v1.AstraConnector, podSecurityContext *corev1.PodSecurityContext) *corev1.PodSecurityContext {
+	restricted := podSecurityContext.DeepCopy()
+	if restricted == nil {
+		restricted = &corev1.PodSecurityContext{}
+	}
+	restricted.RunAsNonRoot = pointer.Bool(true)
+	restricted.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+
+	if IsOpenShift(m) {
+		restricted.RunAsUser = nil
+		restricted.RunAsGroup = nil
+		restricted.FSGroup = nil
+	}
+	return restricted
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/NetApp-Polaris/astra-connector-operator/app/deployer/model"
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+)
+
+func newAstraConnectorOnFlavor(flavor string) *v1.AstraConnector {
+	m := &v1.AstraConnector{}
+	if flavor != "" {
+		m.Status.Cluster = &v1.ClusterStatus{Flavor: flavor}
+	}
+	return m
+}
+
+func TestIsOpenShift(t *testing.T) {
+	assert.False(t, model.IsOpenShift(newAstraConnectorOnFlavor("")))
+	assert.False(t, model.IsOpenShift(newAstraConnectorOnFlavor("gke")))
+	assert.True(t, model.IsOpenShift(newAstraConnectorOnFlavor("openshift")))
+	assert.True(t, model.IsOpenShift(newAstraConnectorOnFlavor("rosa")))
+}
+
+func TestRestrictedSecurityContext(t *testing.T) {
+	base := &corev1.SecurityContext{
+		ReadOnlyRootFilesystem: pointer.Bool(true),
+		RunAsUser:              pointer.Int64(10001),
+		RunAsGroup:             pointer.Int64(20000),
+	}
+
+	t.Run("RestrictedSecurityContext__Kubernetes", func(t *testing.T) {
+		sc := model.RestrictedSecurityContext(newAstraConnectorOnFlavor("gke"), base)
+
+		assert.Equal(t, pointer.Bool(false), sc.AllowPrivilegeEscalation)
+		assert.Equal(t, []corev1.Capability{"ALL"}, sc.Capabilities.Drop)
+		assert.Equal(t, pointer.Bool(true), sc.ReadOnlyRootFilesystem)
+		assert.Equal(t, pointer.Int64(10001), sc.RunAsUser)
+		assert.Equal(t, pointer.Int64(20000), sc.RunAsGroup)
+	})
+
+	t.Run("RestrictedSecurityContext__OpenShift", func(t *testing.T) {
+		sc := model.RestrictedSecurityContext(newAstraConnectorOnFlavor("openshift"), base)
+
+		assert.Equal(t, pointer.Bool(false), sc.AllowPrivilegeEscalation)
+		assert.Nil(t, sc.RunAsUser)
+		assert.Nil(t, sc.RunAsGroup)
+		// The base is shared, it must be left as is
+		assert.Equal(t, pointer.Int64(10001), base.RunAsUser)
+		assert.Nil(t, base.Capabilities)
+	})
+
+	t.Run("RestrictedSecurityContext__NilBase", func(t *testing.T) {
+		sc := model.RestrictedSecurityContext(newAstraConnectorOnFlavor(""), nil)
+		assert.Equal(t, pointer.Bool(false), sc.AllowPrivilegeEscalation)
+	})
+}
+
+func TestRestrictedPodSecurityContext(t *testing.T) {
+	base := &corev1.PodSecurityContext{
+		RunAsUser: pointer.Int64(10001),
+		FSGroup:   pointer.Int64(20000),
+	}
+
+	t.Run("RestrictedPodSecurityContext__Kubernetes", func(t *testing.T) {
+		psc := model.RestrictedPodSecurityContext(newAstraConnectorOnFlavor(""), base)
+
+		assert.Equal(t, pointer.Bool(true), psc.RunAsNonRoot)
+		assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, psc.SeccompProfile.Type)
+		assert.Equal(t, pointer.Int64(10001), psc.RunAsUser)
+		assert.Equal(t, pointer.Int64(20000), psc.FSGroup)
+	})
+
+	t.Run("RestrictedPodSecurityContext__OpenShift", func(t *testing.T) {
+		psc := model.RestrictedPodSecurityContext(newAstraConnectorOnFlavor("okd"), base)
+
+		assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, psc.SeccompProfile.Type)
+		assert.Nil(t, psc.RunAsUser)
+		assert.Nil(t, psc.FSGroup)
+	})
+}
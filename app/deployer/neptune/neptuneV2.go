@@ -35,26 +35,19 @@ func NewNeptuneClientDeployerV2() model.Deployer {
 	return &NeptuneClientDeployerV2{}
 }
 
+// UseServerSideApply Neptune resources are server-side applied, so fields set by other controllers are left alone.
+// The MutateFns are still used when the ServerSideApply feature flag is off.
+func (n NeptuneClientDeployerV2) UseServerSideApply() bool {
+	return true
+}
+
 func (n NeptuneClientDeployerV2) GetDeploymentObjects(m *v1.AstraConnector, ctx context.Context) ([]client.Object, controllerutil.MutateFn, error) {
 	var deps []client.Object
 	log := ctrllog.FromContext(ctx)
 
-	var imageRegistry string
-	var containerImage string
-	var neptuneImage string
-	if m.Spec.ImageRegistry.Name != "" {
-		imageRegistry = m.Spec.ImageRegistry.Name
-	} else {
-		imageRegistry = common.DefaultImageRegistry
-	}
-
-	if m.Spec.Neptune.Image != "" {
-		containerImage = m.Spec.Neptune.Image
-	} else {
-		containerImage = common.NeptuneImageTag
-	}
-
-	neptuneImage = fmt.Sprintf("%s/controller:%s", imageRegistry, containerImage)
+	imageRegistry := m.GetImageRegistry()
+	containerImage := m.GetNeptuneImageTag()
+	neptuneImage := m.GetNeptuneImage()
 	rbacProxyImage := fmt.Sprintf("%s/%s", imageRegistry, common.RbacProxyImage)
 	log.Info("Using Neptune image", "image", neptuneImage)
 
@@ -77,6 +70,21 @@ func (n NeptuneClientDeployerV2) GetDeploymentObjects(m *v1.AstraConnector, ctx
 	maps.Copy(podLabels, m.Spec.Labels)
 	neptuneReplicas := int32(common.NeptuneReplicas)
 
+	managerEnv := getNeptuneEnvVars(
+		imageRegistry,
+		containerImage,
+		m.Spec.Neptune.JobImagePullPolicy,
+		m.Spec.ImageRegistry.Secret,
+		m.Spec.AutoSupport.URL,
+		m.Spec.Labels,
+	)
+	// Route the traffic of the manager to Astra through the proxy
+	managerEnv = append(managerEnv, m.Spec.Proxy.GetEnvVars()...)
+
+	// Neptune only runs on linux nodes of the supported architectures, the user's scheduling is merged on top
+	scheduling := corev1.PodSpec{Affinity: getNeptuneNodeAffinity()}
+	m.Spec.Neptune.Scheduling.ApplyTo(&scheduling)
+
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      common.NeptuneName,
@@ -104,35 +112,11 @@ func (n NeptuneClientDeployerV2) GetDeploymentObjects(m *v1.AstraConnector, ctx
 					Labels: podLabels,
 				},
 				Spec: corev1.PodSpec{
-					Affinity: &corev1.Affinity{
-						NodeAffinity: &corev1.NodeAffinity{
-							RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
-								NodeSelectorTerms: []corev1.NodeSelectorTerm{
-									{
-										MatchExpressions: []corev1.NodeSelectorRequirement{
-											{
-												Key:      "kubernetes.io/arch",
-												Operator: corev1.NodeSelectorOpIn,
-												Values: []string{
-													"amd64",
-													"arm64",
-													"ppc64le",
-													"s390x",
-												},
-											},
-											{
-												Key:      "kubernetes.io/os",
-												Operator: corev1.NodeSelectorOpIn,
-												Values: []string{
-													"linux",
-												},
-											},
-										},
-									},
-								},
-							},
-						},
-					},
+					NodeSelector:              scheduling.NodeSelector,
+					Tolerations:               scheduling.Tolerations,
+					Affinity:                  scheduling.Affinity,
+					TopologySpreadConstraints: scheduling.TopologySpreadConstraints,
+					PriorityClassName:         scheduling.PriorityClassName,
 					Containers: []corev1.Container{
 						{
 							Args: []string{
@@ -160,9 +144,9 @@ func (n NeptuneClientDeployerV2) GetDeploymentObjects(m *v1.AstraConnector, ctx
 									corev1.ResourceMemory: resource.MustParse("128Mi"),
 								},
 							},
-							SecurityContext: &corev1.SecurityContext{
+							SecurityContext: model.RestrictedSecurityContext(m, &corev1.SecurityContext{
 								AllowPrivilegeEscalation: pointer.Bool(false),
-							},
+							}),
 						},
 						{
 							Args: []string{
@@ -173,14 +157,7 @@ func (n NeptuneClientDeployerV2) GetDeploymentObjects(m *v1.AstraConnector, ctx
 								"/manager",
 							},
 							Image: neptuneImage,
-							Env: getNeptuneEnvVars(
-								imageRegistry,
-								containerImage,
-								m.Spec.Neptune.JobImagePullPolicy,
-								m.Spec.ImageRegistry.Secret,
-								m.Spec.AutoSupport.URL,
-								m.Spec.Labels,
-							),
+							Env:   managerEnv,
 							LivenessProbe: &corev1.Probe{
 								ProbeHandler: corev1.ProbeHandler{
 									HTTPGet: &corev1.HTTPGetAction{
@@ -202,15 +179,14 @@ func (n NeptuneClientDeployerV2) GetDeploymentObjects(m *v1.AstraConnector, ctx
 								InitialDelaySeconds: 5,
 								PeriodSeconds:       10,
 							},
-							Resources: getNeptuneResourceLimit(m.Spec.Neptune.ResourceRequirements.Limits,
-								m.Spec.Neptune.ResourceRequirements.Requests),
-							SecurityContext: &corev1.SecurityContext{
+							Resources: common.ResolveResourceRequirements(m.Spec.Neptune.ResourceRequirements, common.GetDefaultNeptuneResources()),
+							SecurityContext: model.RestrictedSecurityContext(m, &corev1.SecurityContext{
 								AllowPrivilegeEscalation: pointer.Bool(false),
 								ReadOnlyRootFilesystem:   pointer.Bool(true),
-							},
+							}),
 						},
 					},
-					SecurityContext:               conf.GetPodSecurityContext(),
+					SecurityContext:               model.RestrictedPodSecurityContext(m, conf.GetPodSecurityContext()),
 					ServiceAccountName:            "neptune-controller-manager",
 					TerminationGracePeriodSeconds: pointer.Int64(10),
 				},
@@ -228,55 +204,47 @@ func (n NeptuneClientDeployerV2) GetDeploymentObjects(m *v1.AstraConnector, ctx
 
 	deps = append(deps, deployment)
 
+	// deployment is overwritten with the Deployment in the cluster before mutateFunc runs, so keep what we want
+	desired := deployment.DeepCopy()
 	mutateFunc := func() error {
-		// Get the containers
-		containers := deployment.Spec.Template.Spec.Containers
-		newContainers := make([]corev1.Container, 0, 2)
-
-		for _, container := range containers {
-			if container.Name == "manager" {
-				container.Env = getNeptuneEnvVars(
-					imageRegistry,
-					containerImage,
-					m.Spec.Neptune.JobImagePullPolicy,
-					m.Spec.ImageRegistry.Secret,
-					m.Spec.AutoSupport.URL,
-					m.Spec.Labels,
-				)
-				container.Image = neptuneImage
-			}
-			newContainers = append(newContainers, container)
-		}
-
-		// Update the containers in the deployment
-		deployment.Spec.Template.Spec.Containers = newContainers
+		model.MutateDeployment(deployment, desired)
 		return nil
 	}
 
 	return deps, mutateFunc, nil
 }
 
-func getNeptuneResourceLimit(limit, request corev1.ResourceList) corev1.ResourceRequirements {
-	var neptuneResourceSize corev1.ResourceRequirements
-	if limit != nil {
-		neptuneResourceSize.Limits = limit
-	} else {
-		// not set let's set default
-		neptuneResourceSize.Limits = corev1.ResourceList{
-			corev1.ResourceMemory: resource.MustParse("2Gi"),
-		}
-	}
-
-	if request != nil {
-		neptuneResourceSize.Requests = request
-	} else {
-		// not set let's set default
-		neptuneResourceSize.Requests = corev1.ResourceList{
-			corev1.ResourceCPU:    resource.MustParse("0.5"),
-			corev1.ResourceMemory: resource.MustParse("2Gi"),
-		}
+// getNeptuneNodeAffinity Returns the built-in node affinity of Neptune, linux nodes of the supported architectures
+func getNeptuneNodeAffinity() *corev1.Affinity {
+	return &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{
+						MatchExpressions: []corev1.NodeSelectorRequirement{
+							{
+								Key:      "kubernetes.io/arch",
+								Operator: corev1.NodeSelectorOpIn,
+								Values: []string{
+									"amd64",
+									"arm64",
+									"ppc64le",
+									"s390x",
+								},
+							},
+							{
+								Key:      "kubernetes.io/os",
+								Operator: corev1.NodeSelectorOpIn,
+								Values: []string{
+									"linux",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
-	return neptuneResourceSize
 }
 
 func getNeptuneEnvVars(imageRegistry, containerImage, jobImagePullPolicy, pullSecret, asupUrl string, mLabels map[string]string) []corev1.EnvVar {
@@ -423,6 +391,11 @@ func (n NeptuneClientDeployerV2) GetRoleBindingObjects(m *v1.AstraConnector, ctx
 	return nil, model.NonMutateFn, nil
 }
 
+// GetSecurityContextConstraintsObjects Neptune needs no SCC of its own, its pods are admitted by restricted-v2 on OpenShift
+func (n NeptuneClientDeployerV2) GetSecurityContextConstraintsObjects(m *v1.AstraConnector, ctx context.Context) ([]client.Object, controllerutil.MutateFn, error) {
+	return nil, model.NonMutateFn, nil
+}
+
 func (n NeptuneClientDeployerV2) GetClusterRoleBindingObjects(m *v1.AstraConnector, ctx context.Context) ([]client.Object, controllerutil.MutateFn, error) {
 	return nil, model.NonMutateFn, nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/NetApp-Polaris/astra-connector-operator/app/deployer/neptune"
@@ -68,6 +69,129 @@ func TestGetDeploymentObjectsV2(t *testing.T) {
 	assert.Equal(t, "test-secret", deployment.Spec.Template.Spec.ImagePullSecrets[0].Name)
 }
 
+func TestGetDeploymentObjectsV2SecurityContext(t *testing.T) {
+	n, m, ctx := createNeptuneDeployerV2()
+	m.Status.Cluster = &v1.ClusterStatus{Flavor: "openshift"}
+
+	deploymentObjects, _, err := n.GetDeploymentObjects(m, ctx)
+	assert.NoError(t, err)
+	podSpec := deploymentObjects[0].(*appsv1.Deployment).Spec.Template.Spec
+
+	assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, podSpec.SecurityContext.SeccompProfile.Type)
+	assert.Nil(t, podSpec.SecurityContext.RunAsUser)
+	for _, container := range podSpec.Containers {
+		assert.Equal(t, false, *container.SecurityContext.AllowPrivilegeEscalation)
+		assert.Equal(t, []corev1.Capability{"ALL"}, container.SecurityContext.Capabilities.Drop)
+		assert.Nil(t, container.SecurityContext.RunAsUser)
+	}
+	assert.Equal(t, true, *podSpec.Containers[1].SecurityContext.ReadOnlyRootFilesystem)
+}
+
+func TestGetDeploymentObjectsV2Proxy(t *testing.T) {
+	n, m, ctx := createNeptuneDeployerV2()
+	m.Spec.Proxy = &v1.Proxy{HTTPSProxy: "http://proxy.example.com:3128", NoProxy: "10.0.0.0/8"}
+
+	deploymentObjects, mutateFunc, err := n.GetDeploymentObjects(m, ctx)
+	assert.NoError(t, err)
+
+	deployment := deploymentObjects[0].(*appsv1.Deployment)
+	manager := deployment.Spec.Template.Spec.Containers[1]
+	assert.Equal(t, "manager", manager.Name)
+	assert.Contains(t, manager.Env, corev1.EnvVar{Name: "HTTPS_PROXY", Value: "http://proxy.example.com:3128"})
+
+	// The proxy env survives the mutate function resetting the env of the manager
+	assert.NoError(t, mutateFunc())
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[1].Env, corev1.EnvVar{Name: "HTTPS_PROXY", Value: "http://proxy.example.com:3128"})
+
+	// The kube-rbac-proxy sidecar does not talk to Astra
+	for _, env := range deployment.Spec.Template.Spec.Containers[0].Env {
+		assert.NotEqual(t, "HTTPS_PROXY", env.Name)
+	}
+}
+
+func TestGetDeploymentObjectsV2Scheduling(t *testing.T) {
+	n, m, ctx := createNeptuneDeployerV2()
+	m.Spec.Neptune.Scheduling = v1.Scheduling{
+		NodeSelector:      map[string]string{"node-role.kubernetes.io/infra": ""},
+		Tolerations:       []corev1.Toleration{{Key: "node-role.kubernetes.io/infra", Operator: corev1.TolerationOpExists}},
+		PriorityClassName: "astra-critical",
+	}
+
+	deploymentObjects, mutateFunc, err := n.GetDeploymentObjects(m, ctx)
+	assert.NoError(t, err)
+	deployment := deploymentObjects[0].(*appsv1.Deployment)
+
+	podSpec := deployment.Spec.Template.Spec
+	assert.Equal(t, map[string]string{"node-role.kubernetes.io/infra": ""}, podSpec.NodeSelector)
+	assert.Equal(t, "node-role.kubernetes.io/infra", podSpec.Tolerations[0].Key)
+	assert.Equal(t, "astra-critical", podSpec.PriorityClassName)
+	// The built-in arch/os node affinity is kept
+	assert.Equal(t, "kubernetes.io/arch",
+		podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Key)
+
+	// An existing Deployment without the scheduling fields gets them on update
+	deployment.Spec.Template.Spec.NodeSelector = nil
+	deployment.Spec.Template.Spec.Tolerations = nil
+	deployment.Spec.Template.Spec.PriorityClassName = ""
+	assert.NoError(t, mutateFunc())
+	assert.Equal(t, podSpec.NodeSelector, deployment.Spec.Template.Spec.NodeSelector)
+	assert.Equal(t, podSpec.Tolerations, deployment.Spec.Template.Spec.Tolerations)
+	assert.Equal(t, "astra-critical", deployment.Spec.Template.Spec.PriorityClassName)
+}
+
+func TestGetDeploymentObjectsV2Update(t *testing.T) {
+	testCases := []struct {
+		name   string
+		update func(m *v1.AstraConnector)
+	}{
+		{"Image", func(m *v1.AstraConnector) { m.Spec.Neptune.Image = "new-image" }},
+		{"ImageRegistry", func(m *v1.AstraConnector) { m.Spec.ImageRegistry.Name = "new-registry" }},
+		{"ImagePullSecret", func(m *v1.AstraConnector) { m.Spec.ImageRegistry.Secret = "new-secret" }},
+		{"JobImagePullPolicy", func(m *v1.AstraConnector) { m.Spec.Neptune.JobImagePullPolicy = "Always" }},
+		{"Resources", func(m *v1.AstraConnector) {
+			m.Spec.Neptune.ResourceRequirements = corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+			}
+		}},
+		{"AutoSupportURL", func(m *v1.AstraConnector) { m.Spec.AutoSupport.URL = "https://new-asup" }},
+		{"Labels", func(m *v1.AstraConnector) { m.Spec.Labels = map[string]string{"Label1": "Value2"} }},
+		{"Proxy", func(m *v1.AstraConnector) { m.Spec.Proxy = &v1.Proxy{HTTPSProxy: "http://proxy.example.com:3128"} }},
+		{"Scheduling", func(m *v1.AstraConnector) {
+			m.Spec.Neptune.Scheduling = v1.Scheduling{Tolerations: []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}}}
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run("GetDeploymentObjects__UpdatePropagates"+tc.name, func(t *testing.T) {
+			n, m, ctx := createNeptuneDeployerV2()
+
+			objects, _, err := n.GetDeploymentObjects(m, ctx)
+			assert.NoError(t, err)
+			existing := objects[0].(*appsv1.Deployment)
+			existing.Spec.Template.Annotations["kubectl.kubernetes.io/restartedAt"] = "2024-01-01T00:00:00Z"
+			existing.Spec.Template.Spec.Containers[1].ReadinessProbe.TimeoutSeconds = 1
+
+			tc.update(m)
+			objects, mutateFunc, err := n.GetDeploymentObjects(m, ctx)
+			assert.NoError(t, err)
+			deployment := objects[0].(*appsv1.Deployment)
+			desired := deployment.DeepCopy()
+
+			// As ctrl.CreateOrUpdate, read the Deployment from the cluster into the object before mutating it
+			*deployment = *existing.DeepCopy()
+			assert.NoError(t, mutateFunc())
+
+			assert.Equal(t, desired.Labels, deployment.Labels)
+			assert.Equal(t, "2024-01-01T00:00:00Z", deployment.Spec.Template.Annotations["kubectl.kubernetes.io/restartedAt"])
+
+			// Apart from the server defaults, the pod spec is the desired one
+			desired.Spec.Template.Spec.Containers[1].ReadinessProbe.TimeoutSeconds = 1
+			desired.Spec.Template.Spec.DeprecatedServiceAccount = desired.Spec.Template.Spec.ServiceAccountName
+			assert.Equal(t, desired.Spec.Template.Spec, deployment.Spec.Template.Spec)
+		})
+	}
+}
+
 func TestUnimplementedObjectsV2(t *testing.T) {
 	n, m, ctx := createNeptuneDeployerV2()
 
@@ -106,6 +230,11 @@ func TestUnimplementedObjectsV2(t *testing.T) {
 	assert.Nil(t, ret)
 	assert.NotNil(t, fn)
 	assert.NoError(t, err)
+
+	ret, fn, err = n.GetSecurityContextConstraintsObjects(m, ctx)
+	assert.Nil(t, ret)
+	assert.NotNil(t, fn)
+	assert.NoError(t, err)
 }
 
 func TestGetServiceObjects(t *testing.T) {
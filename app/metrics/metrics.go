@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "astra_connector_operator"
+
+// Result label values of ReconcilePhaseDuration
+const (
+	ResultSuccess = "success"
+	ResultError   = "error"
+)
+
+// CodeError is the code label of an Astra API request that got no response
+const CodeError = "error"
+
+var (
+	// ReconcilePhaseDuration is the duration of each phase of an AstraConnector reconcile
+	ReconcilePhaseDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "reconcile_phase_duration_seconds",
+		Help:      "Duration of the phases of an AstraConnector reconcile.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"phase", "result"})
+
+	// PrecheckFailures counts the failed runs of each precheck, by the severity of the failure
+	PrecheckFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "precheck_failures_total",
+		Help:      "Number of failed precheck runs by check and severity.",
+	}, []string{"check", "severity"})
+
+	// DeployerApplyErrors counts the resources a deployer failed to create or update
+	DeployerApplyErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "deployer_apply_errors_total",
+		Help:      "Number of errors creating or updating the resources of a deployer by kind.",
+	}, []string{"deployer", "kind"})
+
+	// AstraAPIRequestDuration is the latency of each request made to Astra, by status code
+	AstraAPIRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "astra_api_request_duration_seconds",
+		Help:      "Latency of the requests made to the Astra API by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	// TimeToManaged is the time from the creation of an AstraConnector until its cluster is managed by Astra
+	TimeToManaged = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "time_to_managed_seconds",
+		Help:      "Time from the creation of an AstraConnector until its cluster is managed by Astra.",
+		Buckets:   prometheus.ExponentialBuckets(10, 2, 12),
+	})
+
+	// ConnectorRegistered is 1 for each AstraConnector whose cluster is managed by Astra and 0 otherwise
+	ConnectorRegistered = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "connector_registered",
+		Help:      "Whether the cluster of the AstraConnector is managed by Astra.",
+	}, []string{"namespace", "name"})
+)
+
+// Register Registers the collectors of the operator, main registers them with the controller-runtime registry
+// so they are served on its metrics endpoint
+func Register(registry prometheus.Registerer) error {
+	collectors := []prometheus.Collector{
+		ReconcilePhaseDuration,
+		PrecheckFailures,
+		DeployerApplyErrors,
+		AstraAPIRequestDuration,
+		TimeToManaged,
+		ConnectorRegistered,
+	}
+	for _, collector := range collectors {
+		if err := registry.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ObserveReconcilePhase Records the duration of a reconcile phase that started at start and ended with err
+func ObserveReconcilePhase(phase string, start time.Time, err error) {
+	result := ResultSuccess
+	if err != nil {
+		result = ResultError
+	}
+	ReconcilePhaseDuration.WithLabelValues(phase, result).Observe(time.Since(start).Seconds())
+}
+
+// ObserveAstraAPIRequest Records an Astra API request that started at start, statusCode is 0 if there was no response
+func ObserveAstraAPIRequest(method string, statusCode int, start time.Time) {
+	code := CodeError
+	if statusCode != 0 {
+		code = strconv.Itoa(statusCode)
+	}
+	AstraAPIRequestDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+}
+
+// SetConnectorRegistered Sets whether the cluster of the AstraConnector is managed by Astra
+func SetConnectorRegistered(namespace, name string, registered bool) {
+	value := 0.0
+	if registered {
+		value = 1
+	}
+	ConnectorRegistered.WithLabelValues(namespace, name).Set(value)
+}
+
+// DeleteConnector Removes the series of a deleted AstraConnector
+func DeleteConnector(namespace, name string) {
+	ConnectorRegistered.DeleteLabelValues(namespace, name)
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package metrics_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NetApp-Polaris/astra-connector-operator/app/metrics"
+)
+
+func TestRegister(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	assert.NoError(t, metrics.Register(registry))
+
+	// Registering twice is reported instead of silently dropping the collectors
+	assert.Error(t, metrics.Register(registry))
+}
+
+func TestObserveReconcilePhase(t *testing.T) {
+	metrics.ReconcilePhaseDuration.Reset()
+
+	metrics.ObserveReconcilePhase("precheck", time.Now(), nil)
+	metrics.ObserveReconcilePhase("precheck", time.Now(), errors.New("failed"))
+	metrics.ObserveReconcilePhase("precheck", time.Now(), errors.New("failed"))
+
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.ReconcilePhaseDuration))
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics.ReconcilePhaseDuration.WithLabelValues("precheck", metrics.ResultSuccess).(prometheus.Histogram)))
+}
+
+func TestObserveAstraAPIRequest(t *testing.T) {
+	metrics.AstraAPIRequestDuration.Reset()
+
+	metrics.ObserveAstraAPIRequest("GET", 200, time.Now())
+	metrics.ObserveAstraAPIRequest("GET", 0, time.Now())
+
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.AstraAPIRequestDuration))
+	assert.True(t, metrics.AstraAPIRequestDuration.DeleteLabelValues("GET", "200"))
+	assert.True(t, metrics.AstraAPIRequestDuration.DeleteLabelValues("GET", metrics.CodeError))
+}
+
+func TestSetConnectorRegistered(t *testing.T) {
+	metrics.ConnectorRegistered.Reset()
+
+	metrics.SetConnectorRegistered("astra-connector", "astra-connector", false)
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.ConnectorRegistered.WithLabelValues("astra-connector", "astra-connector")))
+
+	metrics.SetConnectorRegistered("astra-connector", "astra-connector", true)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.ConnectorRegistered.WithLabelValues("astra-connector", "astra-connector")))
+
+	metrics.DeleteConnector("astra-connector", "astra-connector")
+	assert.Equal(t, 0, testutil.CollectAndCount(metrics.ConnectorRegistered))
+}
@@ -7,13 +7,12 @@ package register
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
 	"math"
-	"net"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -23,6 +22,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/NetApp-Polaris/astra-connector-operator/app/metrics"
 	"github.com/NetApp-Polaris/astra-connector-operator/common"
 	"github.com/NetApp-Polaris/astra-connector-operator/details/k8s"
 	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
@@ -68,7 +68,13 @@ func DoRequest(ctx context.Context, client HTTPClient, method, url string, bodyB
 			req.Header.Add("authorization", headerMap.Authorization)
 		}
 
+		requestStart := time.Now()
 		httpResponse, err = client.Do(req)
+		statusCode := 0
+		if err == nil {
+			statusCode = httpResponse.StatusCode
+		}
+		metrics.ObserveAstraAPIRequest(method, statusCode, requestStart)
 		if err == nil && httpResponse.StatusCode >= 200 && httpResponse.StatusCode < 300 {
 			log.Info("Request successful")
 			break
@@ -81,7 +87,9 @@ func DoRequest(ctx context.Context, client HTTPClient, method, url string, bodyB
 		}
 
 		// If the request failed or the server returned a non-2xx status code, wait before retrying
-		time.Sleep(sleepTimeout)
+		if i < retries-1 {
+			time.Sleep(sleepTimeout)
+		}
 	}
 
 	return httpResponse, err, cancel
@@ -89,10 +97,16 @@ func DoRequest(ctx context.Context, client HTTPClient, method, url string, bodyB
 
 type ClusterRegisterUtil interface {
 	GetAPITokenFromSecret(secretName string) (string, string, error)
+	GetCABundle() ([]byte, error)
 	IsClusterManaged() (bool, string, error)
+	UnmanageCluster(clusterId string) (string, error)
 	SetHttpClient(disableTls bool, astraHost string) error
+	CloseIdleConnections()
 }
 
+// UnmanageClusterRetries number of attempts made to unmanage the cluster before giving up
+const UnmanageClusterRetries = 3
+
 type clusterRegisterUtil struct {
 	AstraConnector *v1.AstraConnector
 	Client         HTTPClient
@@ -137,15 +151,6 @@ func GetAstraHostURL(astraConnector *v1.AstraConnector) string {
 	return astraHost
 }
 
-func (c clusterRegisterUtil) getAstraHostFromURL(astraHostURL string) (string, error) {
-	cloudBridgeURLSplit := strings.Split(astraHostURL, "://")
-	if len(cloudBridgeURLSplit) != 2 {
-		errStr := fmt.Sprintf("invalid cloudBridgeURL provided: %s, format - https://hostname", astraHostURL)
-		return "", errors.New(errStr)
-	}
-	return cloudBridgeURLSplit[1], nil
-}
-
 func (c clusterRegisterUtil) readResponseBody(response *http.Response) ([]byte, error) {
 	bodyBytes, err := io.ReadAll(response.Body)
 	if err != nil {
@@ -154,39 +159,59 @@ func (c clusterRegisterUtil) readResponseBody(response *http.Response) ([]byte,
 	return bodyBytes, nil
 }
 
-func (c clusterRegisterUtil) SetHttpClient(disableTls bool, astraHost string) error {
+// SetHttpClient Replaces the HTTP client with one that has its own transport for this AstraConnector
+func (c *clusterRegisterUtil) SetHttpClient(disableTls bool, astraHost string) error {
 	if disableTls {
-		http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
 		c.Log.WithValues("disableTls", disableTls).Info("TLS Validation Disabled! Not for use in production!")
 	}
 
 	if c.AstraConnector.Spec.NatsSyncClient.HostAliasIP != "" {
 		c.Log.WithValues("HostAliasIP", c.AstraConnector.Spec.NatsSyncClient.HostAliasIP).Info("Using the HostAlias IP")
-		cloudBridgeHost, err := c.getAstraHostFromURL(astraHost)
+	}
+
+	transportOptions := TransportOptions{
+		AstraHostURL:      astraHost,
+		SkipTLSValidation: disableTls,
+		HostAliasIP:       c.AstraConnector.Spec.NatsSyncClient.HostAliasIP,
+	}
+
+	if c.AstraConnector.Spec.Astra.CABundleRef != nil {
+		caBundle, err := c.GetCABundle()
 		if err != nil {
 			return err
 		}
-
-		dialer := &net.Dialer{
-			Timeout:   30 * time.Second,
-			KeepAlive: 30 * time.Second,
+		transportOptions.RootCAs, err = NewCertPool(caBundle)
+		if err != nil {
+			return err
 		}
+	}
 
-		http.DefaultTransport.(*http.Transport).DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
-			if addr == cloudBridgeHost+":443" {
-				addr = c.AstraConnector.Spec.NatsSyncClient.HostAliasIP + ":443"
-			}
-			if addr == cloudBridgeHost+":80" {
-				addr = c.AstraConnector.Spec.NatsSyncClient.HostAliasIP + ":80"
-			}
-			return dialer.DialContext(ctx, network, addr)
+	if proxy := c.AstraConnector.Spec.Proxy; proxy != nil && (proxy.HTTPProxy != "" || proxy.HTTPSProxy != "") {
+		c.Log.WithValues("httpProxy", proxy.HTTPProxy, "httpsProxy", proxy.HTTPSProxy, "noProxy", proxy.NoProxy).Info("Using proxy")
+		proxyFunc, err := c.getProxyFunc(proxy)
+		if err != nil {
+			return err
 		}
+		transportOptions.Proxy = proxyFunc
 	}
 
-	c.Client = &http.Client{}
+	httpClient, err := NewHTTPClient(transportOptions)
+	if err != nil {
+		return err
+	}
+
+	c.Client = httpClient
 	return nil
 }
 
+// CloseIdleConnections Closes the idle connections of the HTTP client. SetHttpClient creates a new transport every time,
+// so its connections have to be closed once the ClusterRegisterUtil is no longer used.
+func (c clusterRegisterUtil) CloseIdleConnections() {
+	if closer, ok := c.Client.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
+
 func (c clusterRegisterUtil) IsClusterManaged() (bool, string, error) {
 	apiToken, errorReason, err := c.GetAPITokenFromSecret(c.AstraConnector.Spec.Astra.TokenRef)
 	if err != nil {
@@ -234,6 +259,41 @@ func (c clusterRegisterUtil) IsClusterManaged() (bool, string, error) {
 
 }
 
+// UnmanageCluster Unmanages the cluster in Astra so that it does not linger as a managed cluster once the connector is removed.
+// A cluster that Astra no longer knows about is treated as successfully unmanaged.
+func (c clusterRegisterUtil) UnmanageCluster(clusterId string) (string, error) {
+	apiToken, errorReason, err := c.GetAPITokenFromSecret(c.AstraConnector.Spec.Astra.TokenRef)
+	if err != nil {
+		return errorReason, err
+	}
+
+	astraHost := GetAstraHostURL(c.AstraConnector)
+	url := fmt.Sprintf("%s/accounts/%s/topology/v1/managedClusters/%s", astraHost, c.AstraConnector.Spec.Astra.AccountId, clusterId)
+
+	c.Log.WithValues("ClusterId", clusterId).Info("Unmanaging cluster")
+
+	headerMap := HeaderMap{Authorization: fmt.Sprintf("Bearer %s", apiToken)}
+	response, err, cancel := DoRequest(c.Ctx, c.Client, http.MethodDelete, url, nil, headerMap, c.Log, UnmanageClusterRetries)
+	defer cancel()
+	if err != nil {
+		return CreateErrorMsg("UnmanageCluster", "DELETE /managedCluster error", url, "", "", err), err
+	}
+
+	if response.StatusCode == http.StatusNotFound {
+		c.Log.WithValues("ClusterId", clusterId).Info("Cluster not found in Astra, nothing to unmanage")
+		return "", nil
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		respBody, _ := c.readResponseBody(response)
+		err = fmt.Errorf("unexpected response status %s", response.Status)
+		return CreateErrorMsg("UnmanageCluster", "DELETE /managedCluster non 2xx response", url, response.Status, string(respBody), err), err
+	}
+
+	c.Log.WithValues("ClusterId", clusterId).Info("Cluster unmanaged")
+	return "", nil
+}
+
 type Cluster struct {
 	Type                       string   `json:"type,omitempty"`
 	Version                    string   `json:"version,omitempty"`
@@ -282,6 +342,63 @@ func (c clusterRegisterUtil) GetAPITokenFromSecret(secretName string) (string, s
 	return apiTokenStr, "", nil
 }
 
+// GetCABundle Returns the PEM encoded CA bundle from the ConfigMap or Secret key referenced by spec.astra.caBundleRef
+func (c clusterRegisterUtil) GetCABundle() ([]byte, error) {
+	caBundleRef := c.AstraConnector.Spec.Astra.CABundleRef
+	if caBundleRef == nil {
+		return nil, nil
+	}
+
+	if caBundleRef.ConfigMapKeyRef != nil {
+		configMap := &coreV1.ConfigMap{}
+		name, key := caBundleRef.ConfigMapKeyRef.Name, caBundleRef.ConfigMapKeyRef.Key
+		err := c.K8sClient.Get(c.Ctx, types.NamespacedName{Name: name, Namespace: c.AstraConnector.Namespace}, configMap)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get CA bundle ConfigMap %s", name)
+		}
+		if caBundle, ok := configMap.Data[key]; ok {
+			return []byte(caBundle), nil
+		}
+		if caBundle, ok := configMap.BinaryData[key]; ok {
+			return caBundle, nil
+		}
+		return nil, fmt.Errorf("key %s not found in CA bundle ConfigMap %s", key, name)
+	}
+
+	if caBundleRef.SecretKeyRef != nil {
+		secret := &coreV1.Secret{}
+		name, key := caBundleRef.SecretKeyRef.Name, caBundleRef.SecretKeyRef.Key
+		err := c.K8sClient.Get(c.Ctx, types.NamespacedName{Name: name, Namespace: c.AstraConnector.Namespace}, secret)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get CA bundle Secret %s", name)
+		}
+		if caBundle, ok := secret.Data[key]; ok {
+			return caBundle, nil
+		}
+		return nil, fmt.Errorf("key %s not found in CA bundle Secret %s", key, name)
+	}
+
+	return nil, nil
+}
+
+// getProxyFunc Returns the proxy selection for spec.proxy, with the credentials from its Secret if one is referenced
+func (c clusterRegisterUtil) getProxyFunc(proxy *v1.Proxy) (func(*http.Request) (*url.URL, error), error) {
+	var userInfo *url.Userinfo
+	if proxy.CredentialsSecretRef != "" {
+		secret := &coreV1.Secret{}
+		err := c.K8sClient.Get(c.Ctx, types.NamespacedName{Name: proxy.CredentialsSecretRef, Namespace: c.AstraConnector.Namespace}, secret)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get proxy credentials Secret %s", proxy.CredentialsSecretRef)
+		}
+		userInfo, err = v1.ProxyUserInfo(string(secret.Data[v1.ProxyUsernameKey]), string(secret.Data[v1.ProxyPasswordKey]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid proxy credentials Secret %s", proxy.CredentialsSecretRef)
+		}
+	}
+
+	return NewProxyFunc(proxy.HTTPProxy, proxy.HTTPSProxy, proxy.NoProxy, userInfo)
+}
+
 // CreateErrorMsg creates a standardized error message for HTTP requests.
 // This should be used in all cases that we want to format an error message for CR status updates.
 func CreateErrorMsg(functionName, action, url, status, responseBody string, err error) string {
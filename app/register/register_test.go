@@ -5,17 +5,23 @@
 package register_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"github.com/google/uuid"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"io"
 	coreV1 "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
+	"net/http"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
 	"testing"
 
+	"github.com/NetApp-Polaris/astra-connector-operator/app/metrics"
 	"github.com/NetApp-Polaris/astra-connector-operator/app/register"
 	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
 	"github.com/NetApp-Polaris/astra-connector-operator/mocks"
@@ -51,6 +57,8 @@ type AstraConnectorInput struct {
 	cloudId            bool
 	clusterId          bool
 	invalidHostDetails bool
+	caBundleRef        *v1.CABundleRef
+	proxy              *v1.Proxy
 }
 
 func createClusterRegister(astraConnectorInput AstraConnectorInput) (register.ClusterRegisterUtil, *mocks.HTTPClient, string, client.Client) {
@@ -106,12 +114,37 @@ func createClusterRegister(astraConnectorInput AstraConnectorInput) (register.Cl
 		astraConnector.Spec.NatsSyncClient.HostAliasIP = testIP
 	}
 
+	astraConnector.Spec.Astra.CABundleRef = astraConnectorInput.caBundleRef
+	astraConnector.Spec.Proxy = astraConnectorInput.proxy
+
 	clusterRegisterUtil := register.NewClusterRegisterUtil(astraConnector, mockHttpClient, fakeClient, k8sUtil, log, context.Background())
 	return clusterRegisterUtil, mockHttpClient, apiTokenSecret, fakeClient
 }
 
 // Tests
 
+func TestDoRequestMetrics(t *testing.T) {
+	metrics.AstraAPIRequestDuration.Reset()
+	mockHttpClient := &mocks.HTTPClient{}
+	mockHttpClient.On("Do", mock.Anything).Return(&http.Response{
+		StatusCode: http.StatusNoContent,
+		Body:       io.NopCloser(bytes.NewReader(nil)),
+	}, nil).Once()
+	mockHttpClient.On("Do", mock.Anything).Return(nil, errors.New("connection refused")).Once()
+
+	_, err, cancel := register.DoRequest(ctx, mockHttpClient, http.MethodDelete, "https://astra.example.com", nil, register.HeaderMap{}, testutil.CreateLoggerForTesting(t))
+	cancel()
+	assert.NoError(t, err)
+	_, err, cancel = register.DoRequest(ctx, mockHttpClient, http.MethodGet, "https://astra.example.com", nil, register.HeaderMap{}, testutil.CreateLoggerForTesting(t))
+	cancel()
+	assert.Error(t, err)
+
+	// Each request is recorded with its status code, a request without a response as error
+	assert.Equal(t, 2, promtestutil.CollectAndCount(metrics.AstraAPIRequestDuration))
+	assert.True(t, metrics.AstraAPIRequestDuration.DeleteLabelValues(http.MethodDelete, "204"))
+	assert.True(t, metrics.AstraAPIRequestDuration.DeleteLabelValues(http.MethodGet, metrics.CodeError))
+}
+
 func TestGetAPITokenFromSecret(t *testing.T) {
 	t.Run("GetAPITokenFromSecret__SecretNotPresentReturnsError", func(t *testing.T) {
 		clusterRegisterUtil, _, _, _ := createClusterRegister(AstraConnectorInput{})
@@ -155,3 +188,192 @@ func TestGetAPITokenFromSecret(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestUnmanageCluster(t *testing.T) {
+	t.Run("UnmanageCluster__SecretNotPresentReturnsError", func(t *testing.T) {
+		clusterRegisterUtil, _, _, _ := createClusterRegister(AstraConnectorInput{})
+
+		errorReason, err := clusterRegisterUtil.UnmanageCluster(testClusterId)
+		assert.Equal(t, "Failed to get secret astra-token", errorReason)
+		assert.EqualError(t, err, "secrets \"astra-token\" not found")
+	})
+
+	t.Run("UnmanageCluster__SuccessfulDeleteReturnsNoError", func(t *testing.T) {
+		clusterRegisterUtil, mockHttpClient, _, _ := createClusterRegister(AstraConnectorInput{createTokenSecret: true, clusterId: true})
+		mockHttpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.Method == http.MethodDelete &&
+				strings.HasSuffix(req.URL.Path, "/topology/v1/managedClusters/"+testClusterId) &&
+				req.Header.Get("authorization") == "Bearer auth-token"
+		})).Return(&http.Response{
+			StatusCode: http.StatusNoContent,
+			Status:     "204 No Content",
+			Body:       io.NopCloser(bytes.NewReader(nil)),
+		}, nil)
+
+		errorReason, err := clusterRegisterUtil.UnmanageCluster(testClusterId)
+		assert.Equal(t, "", errorReason)
+		assert.NoError(t, err)
+		mockHttpClient.AssertNumberOfCalls(t, "Do", 1)
+	})
+
+	t.Run("UnmanageCluster__NotFoundReturnsNoError", func(t *testing.T) {
+		clusterRegisterUtil, mockHttpClient, _, _ := createClusterRegister(AstraConnectorInput{createTokenSecret: true, clusterId: true})
+		mockHttpClient.On("Do", mock.Anything).Return(&http.Response{
+			StatusCode: http.StatusNotFound,
+			Status:     "404 Not Found",
+			Body:       io.NopCloser(bytes.NewReader(nil)),
+		}, nil)
+
+		errorReason, err := clusterRegisterUtil.UnmanageCluster(testClusterId)
+		assert.Equal(t, "", errorReason)
+		assert.NoError(t, err)
+	})
+
+	t.Run("UnmanageCluster__Non2xxReturnsError", func(t *testing.T) {
+		clusterRegisterUtil, mockHttpClient, _, _ := createClusterRegister(AstraConnectorInput{createTokenSecret: true, clusterId: true})
+		mockHttpClient.On("Do", mock.Anything).Return(&http.Response{
+			StatusCode: http.StatusInternalServerError,
+			Status:     "500 Internal Server Error",
+			Body:       io.NopCloser(bytes.NewReader([]byte("internal error"))),
+		}, nil)
+
+		errorReason, err := clusterRegisterUtil.UnmanageCluster(testClusterId)
+		assert.EqualError(t, err, "unexpected response status 500 Internal Server Error")
+		assert.Contains(t, errorReason, "DELETE /managedCluster non 2xx response")
+		assert.Contains(t, errorReason, "internal error")
+		mockHttpClient.AssertNumberOfCalls(t, "Do", register.UnmanageClusterRetries)
+	})
+}
+
+// idleClosingHTTPClient records whether the idle connections of the client were closed
+type idleClosingHTTPClient struct {
+	*mocks.HTTPClient
+	closed bool
+}
+
+func (c *idleClosingHTTPClient) CloseIdleConnections() {
+	c.closed = true
+}
+
+func TestCloseIdleConnections(t *testing.T) {
+	t.Run("CloseIdleConnections__ClosesClientConnections", func(t *testing.T) {
+		httpClient := &idleClosingHTTPClient{HTTPClient: &mocks.HTTPClient{}}
+		clusterRegisterUtil := register.NewClusterRegisterUtil(&v1.AstraConnector{}, httpClient, testutil.CreateFakeClient(),
+			nil, testutil.CreateLoggerForTesting(), ctx)
+
+		clusterRegisterUtil.CloseIdleConnections()
+		assert.True(t, httpClient.closed)
+	})
+
+	t.Run("CloseIdleConnections__ClientWithoutConnectionsIsIgnored", func(t *testing.T) {
+		clusterRegisterUtil, _, _, _ := createClusterRegister(AstraConnectorInput{})
+		clusterRegisterUtil.CloseIdleConnections()
+	})
+}
+
+func TestGetCABundle(t *testing.T) {
+	configMapRef := &v1.CABundleRef{ConfigMapKeyRef: &coreV1.ConfigMapKeySelector{
+		LocalObjectReference: coreV1.LocalObjectReference{Name: "astra-ca"},
+		Key:                  "ca-bundle.pem",
+	}}
+	secretRef := &v1.CABundleRef{SecretKeyRef: &coreV1.SecretKeySelector{
+		LocalObjectReference: coreV1.LocalObjectReference{Name: "astra-ca"},
+		Key:                  "ca.crt",
+	}}
+
+	t.Run("GetCABundle__NoReferenceReturnsNil", func(t *testing.T) {
+		clusterRegisterUtil, _, _, _ := createClusterRegister(AstraConnectorInput{})
+
+		caBundle, err := clusterRegisterUtil.GetCABundle()
+		assert.NoError(t, err)
+		assert.Nil(t, caBundle)
+	})
+
+	t.Run("GetCABundle__ConfigMapKeyIsReturned", func(t *testing.T) {
+		clusterRegisterUtil, _, _, fakeClient := createClusterRegister(AstraConnectorInput{caBundleRef: configMapRef})
+		_ = fakeClient.Create(ctx, &coreV1.ConfigMap{
+			ObjectMeta: metaV1.ObjectMeta{Name: "astra-ca", Namespace: testNamespace},
+			Data:       map[string]string{"ca-bundle.pem": "pem-data"},
+		})
+
+		caBundle, err := clusterRegisterUtil.GetCABundle()
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("pem-data"), caBundle)
+	})
+
+	t.Run("GetCABundle__SecretKeyIsReturned", func(t *testing.T) {
+		clusterRegisterUtil, _, _, fakeClient := createClusterRegister(AstraConnectorInput{caBundleRef: secretRef})
+		_ = fakeClient.Create(ctx, &coreV1.Secret{
+			ObjectMeta: metaV1.ObjectMeta{Name: "astra-ca", Namespace: testNamespace},
+			Data:       map[string][]byte{"ca.crt": []byte("pem-data")},
+		})
+
+		caBundle, err := clusterRegisterUtil.GetCABundle()
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("pem-data"), caBundle)
+	})
+
+	t.Run("GetCABundle__MissingKeyReturnsError", func(t *testing.T) {
+		clusterRegisterUtil, _, _, fakeClient := createClusterRegister(AstraConnectorInput{caBundleRef: secretRef})
+		_ = fakeClient.Create(ctx, &coreV1.Secret{
+			ObjectMeta: metaV1.ObjectMeta{Name: "astra-ca", Namespace: testNamespace},
+			Data:       map[string][]byte{"other": []byte("pem-data")},
+		})
+
+		_, err := clusterRegisterUtil.GetCABundle()
+		assert.EqualError(t, err, "key ca.crt not found in CA bundle Secret astra-ca")
+	})
+
+	t.Run("GetCABundle__MissingConfigMapReturnsError", func(t *testing.T) {
+		clusterRegisterUtil, _, _, _ := createClusterRegister(AstraConnectorInput{caBundleRef: configMapRef})
+
+		_, err := clusterRegisterUtil.GetCABundle()
+		assert.ErrorContains(t, err, "failed to get CA bundle ConfigMap astra-ca")
+	})
+
+	t.Run("SetHttpClient__InvalidCABundleReturnsError", func(t *testing.T) {
+		clusterRegisterUtil, _, _, fakeClient := createClusterRegister(AstraConnectorInput{caBundleRef: configMapRef})
+		_ = fakeClient.Create(ctx, &coreV1.ConfigMap{
+			ObjectMeta: metaV1.ObjectMeta{Name: "astra-ca", Namespace: testNamespace},
+			Data:       map[string]string{"ca-bundle.pem": "not a certificate"},
+		})
+
+		err := clusterRegisterUtil.SetHttpClient(false, "https://astra.netapp.io")
+		assert.EqualError(t, err, "no PEM encoded certificates found in CA bundle")
+	})
+}
+
+func TestSetHttpClientProxy(t *testing.T) {
+	t.Run("SetHttpClient__MissingProxyCredentialsSecretReturnsError", func(t *testing.T) {
+		clusterRegisterUtil, _, _, _ := createClusterRegister(AstraConnectorInput{
+			proxy: &v1.Proxy{HTTPSProxy: "http://proxy.example.com:3128", CredentialsSecretRef: "proxy-credentials"},
+		})
+
+		err := clusterRegisterUtil.SetHttpClient(false, "https://astra.netapp.io")
+		assert.ErrorContains(t, err, "failed to get proxy credentials Secret proxy-credentials")
+	})
+
+	t.Run("SetHttpClient__ProxyWithCredentialsSecretSucceeds", func(t *testing.T) {
+		clusterRegisterUtil, _, _, fakeClient := createClusterRegister(AstraConnectorInput{
+			proxy: &v1.Proxy{HTTPSProxy: "http://proxy.example.com:3128", CredentialsSecretRef: "proxy-credentials"},
+		})
+		_ = fakeClient.Create(ctx, &coreV1.Secret{
+			ObjectMeta: metaV1.ObjectMeta{Name: "proxy-credentials", Namespace: testNamespace},
+			Data:       map[string][]byte{v1.ProxyUsernameKey: []byte("user"), v1.ProxyPasswordKey: []byte("pass")},
+		})
+
+		assert.NoError(t, clusterRegisterUtil.SetHttpClient(false, "https://astra.netapp.io"))
+	})
+	t.Run("SetHttpClient__UnencodedProxyCredentialsReturnError", func(t *testing.T) {
+		clusterRegisterUtil, _, _, fakeClient := createClusterRegister(AstraConnectorInput{
+			proxy: &v1.Proxy{HTTPSProxy: "http://proxy.example.com:3128", CredentialsSecretRef: "proxy-credentials"},
+		})
+		_ = fakeClient.Create(ctx, &coreV1.Secret{
+			ObjectMeta: metaV1.ObjectMeta{Name: "proxy-credentials", Namespace: testNamespace},
+			Data:       map[string][]byte{v1.ProxyUsernameKey: []byte("user"), v1.ProxyPasswordKey: []byte("p@ss")},
+		})
+
+		err := clusterRegisterUtil.SetHttpClient(false, "https://astra.netapp.io")
+		assert.ErrorContains(t, err, "invalid proxy credentials Secret proxy-credentials: invalid password")
+	})
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package register
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/http/httpproxy"
+)
+
+const (
+	dialTimeout           = 30 * time.Second
+	dialKeepAlive         = 30 * time.Second
+	tlsHandshakeTimeout   = 10 * time.Second
+	responseHeaderTimeout = 1 * time.Minute
+	idleConnTimeout       = 90 * time.Second
+)
+
+// TransportOptions Per AstraConnector settings of the HTTP transport used to talk to Astra
+type TransportOptions struct {
+	// AstraHostURL is the URL of Astra, e.g. https://astra.netapp.io
+	AstraHostURL string
+	// SkipTLSValidation disables verification of the certificate presented by Astra
+	SkipTLSValidation bool
+	// RootCAs if set, is the pool used to verify the certificate presented by Astra
+	RootCAs *x509.CertPool
+	// HostAliasIP if set, connections to the host of AstraHostURL are made to this IP instead
+	HostAliasIP string
+	// Proxy selects the proxy for a request, nil uses the proxy from the operator environment
+	Proxy func(*http.Request) (*url.URL, error)
+}
+
+// NewHTTPTransport Builds an isolated transport from the options, http.DefaultTransport is never modified
+// so the settings of one AstraConnector cannot leak into any other HTTP call the operator makes.
+func NewHTTPTransport(options TransportOptions) (*http.Transport, error) {
+	dialer := &net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: dialKeepAlive,
+	}
+
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       idleConnTimeout,
+		TLSHandshakeTimeout:   tlsHandshakeTimeout,
+		ResponseHeaderTimeout: responseHeaderTimeout,
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig:       &tls.Config{MinVersion: tls.VersionTLS12},
+	}
+
+	if options.Proxy != nil {
+		transport.Proxy = options.Proxy
+	}
+
+	if options.RootCAs != nil {
+		transport.TLSClientConfig.RootCAs = options.RootCAs
+	}
+
+	if options.SkipTLSValidation {
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	}
+
+	if options.HostAliasIP != "" {
+		astraHost, err := getAstraHostFromURL(options.AstraHostURL)
+		if err != nil {
+			return nil, err
+		}
+		transport.DialContext = hostAliasDialContext(dialer, astraHost, options.HostAliasIP)
+	}
+
+	return transport, nil
+}
+
+// NewHTTPClient Returns an HTTP client that uses its own transport built from the options
+func NewHTTPClient(options TransportOptions) (*http.Client, error) {
+	transport, err := NewHTTPTransport(options)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: transport}, nil
+}
+
+// NewProxyFunc Returns the proxy selection for the given proxy URLs, requests to hosts matched by noProxy are not proxied.
+// If userInfo is set it is used to authenticate with both proxies.
+func NewProxyFunc(httpProxy, httpsProxy, noProxy string, userInfo *url.Userinfo) (func(*http.Request) (*url.URL, error), error) {
+	httpProxy, err := proxyURLWithUserInfo(httpProxy, userInfo)
+	if err != nil {
+		return nil, err
+	}
+	httpsProxy, err = proxyURLWithUserInfo(httpsProxy, userInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	proxyFunc := (&httpproxy.Config{
+		HTTPProxy:  httpProxy,
+		HTTPSProxy: httpsProxy,
+		NoProxy:    noProxy,
+	}).ProxyFunc()
+
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}, nil
+}
+
+// proxyURLWithUserInfo Sets the user info on the proxy URL, a URL without a scheme is treated as http
+func proxyURLWithUserInfo(proxyURL string, userInfo *url.Userinfo) (string, error) {
+	if proxyURL == "" || userInfo == nil {
+		return proxyURL, nil
+	}
+	if !strings.Contains(proxyURL, "://") {
+		proxyURL = "http://" + proxyURL
+	}
+
+	parsedURL, err := url.Parse(proxyURL)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid proxy URL %s", proxyURL)
+	}
+	parsedURL.User = userInfo
+	return parsedURL.String(), nil
+}
+
+// NewCertPool Returns the system cert pool with the certificates of the PEM encoded CA bundle added to it
+func NewCertPool(caBundle []byte) (*x509.CertPool, error) {
+	certPool, err := x509.SystemCertPool()
+	if err != nil || certPool == nil {
+		certPool = x509.NewCertPool()
+	}
+
+	if !certPool.AppendCertsFromPEM(caBundle) {
+		return nil, errors.New("no PEM encoded certificates found in CA bundle")
+	}
+	return certPool, nil
+}
+
+// hostAliasDialContext Dials hostAliasIP instead of astraHost, on the same port, any other address is dialed unchanged
+func hostAliasDialContext(dialer *net.Dialer, astraHost, hostAliasIP string) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err == nil && host == astraHost {
+			addr = net.JoinHostPort(hostAliasIP, port)
+		}
+		return dialer.DialContext(ctx, network, addr)
+	}
+}
+
+// getAstraHostFromURL Returns the hostname of the Astra URL, format - https://hostname
+func getAstraHostFromURL(astraHostURL string) (string, error) {
+	parsedURL, err := url.Parse(astraHostURL)
+	if err != nil || parsedURL.Scheme == "" || parsedURL.Hostname() == "" {
+		return "", errors.New(fmt.Sprintf("invalid cloudBridgeURL provided: %s, format - https://hostname", astraHostURL))
+	}
+	return parsedURL.Hostname(), nil
+}
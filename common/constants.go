@@ -8,6 +8,9 @@ import (
 	_ "embed"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
 	"github.com/NetApp-Polaris/astra-connector-operator/app/conf"
 )
 
@@ -21,6 +24,8 @@ const (
 	NatsSyncClientDefaultImage          = "natssync-client:2.2.202402012115"
 	NatsSyncClientDefaultCloudBridgeURL = "https://astra.netapp.io"
 
+	DefaultTokenRef = "astra-api-token"
+
 	NeptuneName = "neptune-controller-manager"
 
 	NeptuneMetricServicePort     = 8443
@@ -32,6 +37,23 @@ const (
 	ConnectorWatcherCapability = "watcherV1"
 
 	RbacProxyImage = "kube-rbac-proxy:v0.14.1"
+
+	// CABundleVolumeName is the volume the CA bundle referenced by spec.astra.caBundleRef is mounted from,
+	// the astraconnect container finds it at CABundleMountPath/CABundleFileName
+	CABundleVolumeName = "astra-ca-bundle"
+	CABundleMountPath  = "/etc/astra-connector/ca"
+	CABundleFileName   = "ca.crt"
+
+	// OwnerNameLabel and OwnerNamespaceLabel identify the AstraConnector that created a cluster scoped resource,
+	// these resources cannot have an owner reference to a namespaced object
+	OwnerNameLabel      = "astra.netapp.io/owner-name"
+	OwnerNamespaceLabel = "astra.netapp.io/owner-namespace"
+
+	// FieldManager is the field manager the operator server-side applies its resources with
+	FieldManager = "astra-connector-operator"
+	// LegacyFieldManager is the field manager of the client-side updates made by CreateOrUpdateResource,
+	// the default field manager of the manager binary. Fields it owns are taken over by server-side apply.
+	LegacyFieldManager = "manager"
 )
 
 // Embed image tags
@@ -67,3 +89,44 @@ func GetConnectorCapabilities() []string {
 	}
 	return capabilities
 }
+
+// ResolveResourceRequirements returns the container resources for the resources set in the CR.
+// The defaults are only used when neither limits nor requests are set. Missing requests are taken from the limits,
+// the same as the API server does, so a lower limit never ends up below a default request.
+func ResolveResourceRequirements(requirements, defaults corev1.ResourceRequirements) corev1.ResourceRequirements {
+	if requirements.Limits == nil && requirements.Requests == nil {
+		return *defaults.DeepCopy()
+	}
+
+	resolved := *requirements.DeepCopy()
+	if resolved.Requests == nil {
+		resolved.Requests = resolved.Limits.DeepCopy()
+	}
+	return resolved
+}
+
+// GetDefaultConnectorResources returns the astraconnect container resources used when none are set in the CR
+func GetDefaultConnectorResources() corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("0.1"),
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+	}
+}
+
+// GetDefaultNeptuneResources returns the Neptune manager container resources used when none are set in the CR
+func GetDefaultNeptuneResources() corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("2Gi"),
+		},
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("0.5"),
+			corev1.ResourceMemory: resource.MustParse("2Gi"),
+		},
+	}
+}
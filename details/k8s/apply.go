@@ -0,0 +1,223 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/NetApp-Polaris/astra-connector-operator/common"
+	"github.com/NetApp-Polaris/astra-connector-operator/util"
+)
+
+// conflictManagerRegexp extracts the field manager from a conflict cause, e.g. conflict with "kubectl" using apps/v1
+var conflictManagerRegexp = regexp.MustCompile(`conflict with "([^"]*)"`)
+
+// ApplyConflictError Reports the fields of a resource owned by other field managers that server-side apply did not take over
+type ApplyConflictError struct {
+	Kind      string
+	Namespace string
+	Name      string
+	// Conflicts is the message of each conflicting field as reported by the API server
+	Conflicts []string
+}
+
+func (e *ApplyConflictError) Error() string {
+	return fmt.Sprintf("apply of %s %s/%s conflicts with other field managers: %s",
+		e.Kind, e.Namespace, e.Name, strings.Join(e.Conflicts, "; "))
+}
+
+// ApplyResource Server-side applies the resource with the operator's field manager instead of running a MutateFn.
+// The resource holds every field the operator wants to own, fields owned by it in an earlier apply and no longer set
+// are removed by the API server, fields set by other controllers are left alone.
+// A conflict with another field manager is returned as an ApplyConflictError, unless all of the conflicts are with the
+// client-side updates of CreateOrUpdateResource, whose fields are taken over.
+// On success the resource is updated with the object returned by the API server and, as CreateOrUpdateResource,
+// whether it was created, updated or unchanged is returned.
+func (r *K8sUtil) ApplyResource(ctx context.Context, resource client.Object, owner client.Object) (string, error) {
+	err := SetOwner(resource, owner, r.Client.Scheme())
+	if err != nil {
+		return "", err
+	}
+
+	// Only read to tell whether the apply changed anything
+	existing := resource.DeepCopyObject().(client.Object)
+	err = r.Client.Get(ctx, client.ObjectKeyFromObject(resource), existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return "", err
+	}
+	existingVersion := ""
+	if err == nil {
+		existingVersion = existing.GetResourceVersion()
+	}
+
+	applyObject, err := ToApplyObject(resource, r.Client.Scheme())
+	if err != nil {
+		return "", err
+	}
+
+	err = r.Client.Patch(ctx, applyObject, client.Apply, client.FieldOwner(common.FieldManager))
+	if err != nil && apierrors.IsConflict(err) {
+		conflicts, legacyOnly := getApplyConflicts(err)
+		if !legacyOnly {
+			return "", &ApplyConflictError{
+				Kind:      applyObject.GetKind(),
+				Namespace: resource.GetNamespace(),
+				Name:      resource.GetName(),
+				Conflicts: conflicts,
+			}
+		}
+		r.Log.Info("Taking over fields from client-side updates", "kind", applyObject.GetKind(),
+			"namespace", resource.GetNamespace(), "name", resource.GetName(), "conflicts", conflicts)
+		err = r.Client.Patch(ctx, applyObject, client.Apply, client.FieldOwner(common.FieldManager), client.ForceOwnership)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(applyObject.Object, resource)
+	if err != nil {
+		return "", err
+	}
+
+	if existingVersion == "" {
+		return string(controllerutil.OperationResultCreated), nil
+	}
+	if resource.GetResourceVersion() == existingVersion {
+		return string(controllerutil.OperationResultNone), nil
+	}
+	return string(controllerutil.OperationResultUpdated), nil
+}
+
+// SetOwner Sets the owner of the resource as the operator does on create, an owner reference for a namespaced resource
+// and the owner labels for a cluster scoped one
+func SetOwner(resource client.Object, owner client.Object, scheme *runtime.Scheme) error {
+	if util.IsNil(owner) {
+		return nil
+	}
+	if isNamespaceScoped(resource) {
+		return ctrl.SetControllerReference(owner, resource, scheme)
+	}
+	return withOwnerLabels(resource, owner, nil)()
+}
+
+// ToApplyObject Returns the apply configuration of the resource, which holds only the fields the operator sets
+func ToApplyObject(resource client.Object, scheme *runtime.Scheme) (*unstructured.Unstructured, error) {
+	gvk, err := apiutil.GVKForObject(resource, scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	// A typed object always carries its struct fields and nil pointers, e.g. resources: {} or selector: null, applying them
+	// would make the operator own fields it never set
+	removeZeroStructFields(reflect.ValueOf(resource), content)
+
+	applyObject := &unstructured.Unstructured{Object: content}
+	applyObject.SetGroupVersionKind(gvk)
+	// These are set by the API server
+	applyObject.SetResourceVersion("")
+	applyObject.SetManagedFields(nil)
+	unstructured.RemoveNestedField(applyObject.Object, "status")
+	return applyObject, nil
+}
+
+// removeZeroStructFields Removes the fields of the converted object that are null or whose Go field is a struct that is
+// not set. Only non-pointer structs are removed, an empty pointer struct such as emptyDir: {} is set on purpose.
+func removeZeroStructFields(value reflect.Value, content map[string]interface{}) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+		if name == "-" {
+			continue
+		}
+		fieldValue := value.Field(i)
+		if strings.Contains(tag, ",inline") || (field.Anonymous && name == "") {
+			removeZeroStructFields(fieldValue, content)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldContent, ok := content[name]
+		if !ok {
+			continue
+		}
+		if fieldContent == nil || fieldValue.Kind() == reflect.Struct && fieldValue.IsZero() {
+			delete(content, name)
+			continue
+		}
+		switch fieldContent := fieldContent.(type) {
+		case map[string]interface{}:
+			removeZeroStructFields(fieldValue, fieldContent)
+		case []interface{}:
+			if fieldValue.Kind() != reflect.Slice || fieldValue.Len() != len(fieldContent) {
+				continue
+			}
+			for j, item := range fieldContent {
+				if itemContent, ok := item.(map[string]interface{}); ok {
+					removeZeroStructFields(fieldValue.Index(j), itemContent)
+				}
+			}
+		}
+	}
+}
+
+// getApplyConflicts Returns the conflicts of a failed apply and whether all of them are with LegacyFieldManager
+func getApplyConflicts(err error) ([]string, bool) {
+	var statusErr apierrors.APIStatus
+	if !errors.As(err, &statusErr) || statusErr.Status().Details == nil || len(statusErr.Status().Details.Causes) == 0 {
+		return []string{err.Error()}, false
+	}
+
+	var conflicts []string
+	legacyOnly := true
+	for _, cause := range statusErr.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		conflicts = append(conflicts, fmt.Sprintf("%s: %s", cause.Message, cause.Field))
+		match := conflictManagerRegexp.FindStringSubmatch(cause.Message)
+		if match == nil || match[1] != common.LegacyFieldManager {
+			legacyOnly = false
+		}
+	}
+	if len(conflicts) == 0 {
+		return []string{err.Error()}, false
+	}
+	return conflicts, legacyOnly
+}
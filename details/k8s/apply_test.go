@@ -0,0 +1,228 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package k8s_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/NetApp-Polaris/astra-connector-operator/common"
+	"github.com/NetApp-Polaris/astra-connector-operator/details/k8s"
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+	testutil "github.com/NetApp-Polaris/astra-connector-operator/test/test-util"
+)
+
+type applyCall struct {
+	object  *unstructured.Unstructured
+	options client.PatchOptions
+}
+
+// createApplyK8sUtil Returns a K8sUtil whose client records every apply and answers it with the errors given, in order.
+// The fake client does not implement server-side apply, so a successful apply only sets the resource version to appliedVersion.
+func createApplyK8sUtil(t *testing.T, existing []client.Object, appliedVersion string, applyErrors ...error) (k8s.K8sUtilInterface, *[]applyCall) {
+	var calls []applyCall
+	fakeClient := fakeclient.NewClientBuilder().
+		WithScheme(testutil.CreateFakeClient().Scheme()).
+		WithObjects(existing...).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				assert.Equal(t, types.ApplyPatchType, patch.Type())
+				options := client.PatchOptions{}
+				options.ApplyOptions(opts)
+				calls = append(calls, applyCall{object: obj.(*unstructured.Unstructured).DeepCopy(), options: options})
+
+				if len(calls) <= len(applyErrors) && applyErrors[len(calls)-1] != nil {
+					return applyErrors[len(calls)-1]
+				}
+				obj.SetResourceVersion(appliedVersion)
+				return nil
+			},
+		}).Build()
+	return k8s.NewK8sUtil(fakeClient, fake.NewSimpleClientset(), testutil.CreateLoggerForTesting(t)), &calls
+}
+
+func newApplyOwner() *v1.AstraConnector {
+	return &v1.AstraConnector{ObjectMeta: metav1.ObjectMeta{Name: "astra-connector", Namespace: "astra-connector", UID: "owner-uid"}}
+}
+
+func newApplyConflict(managers ...string) error {
+	var causes []metav1.StatusCause
+	for _, manager := range managers {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "` + manager + `" using apps/v1`,
+			Field:   ".spec.replicas",
+		})
+	}
+	return apierrors.NewApplyConflict(causes, "Apply failed with conflicts")
+}
+
+func TestApplyResource(t *testing.T) {
+	t.Run("ApplyResource__AppliesWithFieldManager", func(t *testing.T) {
+		k8sUtil, calls := createApplyK8sUtil(t, nil, "1")
+		deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "astra-connector"}}
+
+		result, err := k8sUtil.ApplyResource(ctx, deployment, newApplyOwner())
+		assert.NoError(t, err)
+		assert.Equal(t, "created", result)
+
+		assert.Equal(t, 1, len(*calls))
+		call := (*calls)[0]
+		assert.Equal(t, common.FieldManager, call.options.FieldManager)
+		assert.Nil(t, call.options.Force)
+
+		applied := call.object
+		assert.Equal(t, "apps/v1", applied.GetAPIVersion())
+		assert.Equal(t, "Deployment", applied.GetKind())
+		assert.Equal(t, types.UID("owner-uid"), applied.GetOwnerReferences()[0].UID)
+		_, hasStatus := applied.Object["status"]
+		assert.False(t, hasStatus)
+		_, hasCreationTimestamp, _ := unstructured.NestedFieldNoCopy(applied.Object, "metadata", "creationTimestamp")
+		assert.False(t, hasCreationTimestamp)
+
+		// The resource is updated with the object returned by the API server
+		assert.Equal(t, "1", deployment.ResourceVersion)
+	})
+
+	t.Run("ApplyResource__ResultTellsWhetherTheResourceChanged", func(t *testing.T) {
+		existing := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "astra-connector"}}
+
+		k8sUtil, _ := createApplyK8sUtil(t, []client.Object{existing.DeepCopy()}, "999")
+		result, err := k8sUtil.ApplyResource(ctx, existing.DeepCopy(), newApplyOwner())
+		assert.NoError(t, err)
+		assert.Equal(t, "unchanged", result)
+
+		k8sUtil, _ = createApplyK8sUtil(t, []client.Object{existing.DeepCopy()}, "1000")
+		result, err = k8sUtil.ApplyResource(ctx, existing.DeepCopy(), newApplyOwner())
+		assert.NoError(t, err)
+		assert.Equal(t, "updated", result)
+	})
+
+	t.Run("ApplyResource__ClusterScopedResourceGetsOwnerLabels", func(t *testing.T) {
+		k8sUtil, calls := createApplyK8sUtil(t, nil, "1")
+		clusterRole := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+
+		_, err := k8sUtil.ApplyResource(ctx, clusterRole, newApplyOwner())
+		assert.NoError(t, err)
+
+		applied := (*calls)[0].object
+		assert.Empty(t, applied.GetOwnerReferences())
+		assert.Equal(t, "astra-connector", applied.GetLabels()[common.OwnerNameLabel])
+		assert.Equal(t, "astra-connector", applied.GetLabels()[common.OwnerNamespaceLabel])
+	})
+
+	t.Run("ApplyResource__ConflictIsReported", func(t *testing.T) {
+		k8sUtil, calls := createApplyK8sUtil(t, nil, "1", newApplyConflict("kubectl-edit", common.LegacyFieldManager))
+		deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "astra-connector"}}
+
+		_, err := k8sUtil.ApplyResource(ctx, deployment, newApplyOwner())
+
+		var conflictErr *k8s.ApplyConflictError
+		assert.True(t, errors.As(err, &conflictErr))
+		assert.Equal(t, "Deployment", conflictErr.Kind)
+		assert.Equal(t, []string{
+			`conflict with "kubectl-edit" using apps/v1: .spec.replicas`,
+			`conflict with "manager" using apps/v1: .spec.replicas`,
+		}, conflictErr.Conflicts)
+		assert.Contains(t, err.Error(), "apply of Deployment astra-connector/test conflicts with other field managers")
+		// Fields of other managers are never forced
+		assert.Equal(t, 1, len(*calls))
+	})
+
+	t.Run("ApplyResource__FieldsOfClientSideUpdatesAreTakenOver", func(t *testing.T) {
+		k8sUtil, calls := createApplyK8sUtil(t, nil, "1", newApplyConflict(common.LegacyFieldManager))
+		deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "astra-connector"}}
+
+		_, err := k8sUtil.ApplyResource(ctx, deployment, newApplyOwner())
+		assert.NoError(t, err)
+
+		assert.Equal(t, 2, len(*calls))
+		assert.Nil(t, (*calls)[0].options.Force)
+		assert.True(t, *(*calls)[1].options.Force)
+		assert.Equal(t, common.FieldManager, (*calls)[1].options.FieldManager)
+	})
+
+	t.Run("ApplyResource__OtherErrorsAreReturned", func(t *testing.T) {
+		k8sUtil, _ := createApplyK8sUtil(t, nil, "1", apierrors.NewForbidden(appsv1.Resource("deployments"), "test", errors.New("denied")))
+		deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "astra-connector"}}
+
+		_, err := k8sUtil.ApplyResource(ctx, deployment, newApplyOwner())
+		assert.True(t, apierrors.IsForbidden(err))
+	})
+}
+
+func TestToApplyObject(t *testing.T) {
+	scheme := testutil.CreateFakeClient().Scheme()
+
+	t.Run("ToApplyObject__UnsetStructsAreRemoved", func(t *testing.T) {
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "astraconnect", Namespace: "astra-connector", ResourceVersion: "1"},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "astraconnect"}},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "astraconnect", Image: "astraconnect:1.0"}},
+						Volumes: []corev1.Volume{{
+							Name:         "tmp",
+							VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+						}},
+					},
+				},
+			},
+		}
+
+		applyObject, err := k8s.ToApplyObject(deployment, scheme)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "Deployment", applyObject.GetKind())
+		assert.Equal(t, "", applyObject.GetResourceVersion())
+		for _, path := range [][]string{
+			{"metadata", "creationTimestamp"},
+			{"spec", "template", "metadata", "creationTimestamp"},
+			{"spec", "strategy"},
+			{"spec", "selector"},
+			{"status"},
+		} {
+			_, found, _ := unstructured.NestedFieldNoCopy(applyObject.Object, path...)
+			assert.False(t, found, "%v should not be applied", path)
+		}
+
+		labels, _, _ := unstructured.NestedStringMap(applyObject.Object, "spec", "template", "metadata", "labels")
+		assert.Equal(t, map[string]string{"app": "astraconnect"}, labels)
+		containers, _, _ := unstructured.NestedSlice(applyObject.Object, "spec", "template", "spec", "containers")
+		assert.Equal(t, []interface{}{map[string]interface{}{"name": "astraconnect", "image": "astraconnect:1.0"}}, containers)
+		// An empty pointer struct is kept, the volume would have no source otherwise
+		volumes, _, _ := unstructured.NestedSlice(applyObject.Object, "spec", "template", "spec", "volumes")
+		assert.Equal(t, []interface{}{map[string]interface{}{"name": "tmp", "emptyDir": map[string]interface{}{}}}, volumes)
+	})
+
+	t.Run("ToApplyObject__UnstructuredIsUnchanged", func(t *testing.T) {
+		resource := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": "astra", "namespace": "astra-connector"},
+			"data":       map[string]interface{}{},
+		}}
+
+		applyObject, err := k8s.ToApplyObject(resource, scheme)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{}, applyObject.Object["data"])
+		assert.Equal(t, "astra", applyObject.GetName())
+	})
+}
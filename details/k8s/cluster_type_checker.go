@@ -1,12 +1,12 @@
 package k8s
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -21,77 +21,143 @@ const (
 	FlavorRKE2       = "rke2"
 	FlavorTanzu      = "tanzu"
 	FlavorAnthos     = "anthos"
+	FlavorK3s        = "k3s"
+	FlavorKind       = "kind"
+	FlavorMicroK8s   = "microk8s"
+	FlavorOKD        = "okd"
+	FlavorROSA       = "rosa"
+	FlavorARO        = "aro"
+	FlavorEKSA       = "eks-anywhere"
+	FlavorCharmed    = "charmed-kubernetes"
 
 	openShiftApiServerName = "openshift-apiserver"
 )
 
 type ClusterTypeCheckerInterface interface {
-	DetermineClusterType() string
+	DetermineClusterType() (string, error)
+	GetOpenshiftVersion() (string, error)
+}
+
+// IsOpenShiftFlavor returns true for OpenShift and the flavors based on it
+func IsOpenShiftFlavor(flavor string) bool {
+	switch flavor {
+	case FlavorOpenShift, FlavorOKD, FlavorROSA, FlavorARO:
+		return true
+	default:
+		return false
+	}
 }
 
 type ClusterTypeChecker struct {
 	K8sUtil K8sUtilInterface
 	Log     logr.Logger
+
+	// apiGroups are the API groups served by the cluster, read once per DetermineClusterType
+	apiGroups map[string]bool
 }
 
 func NewClusterTypeChecker(k8sUtil K8sUtilInterface, log logr.Logger) ClusterTypeCheckerInterface {
 	return &ClusterTypeChecker{K8sUtil: k8sUtil, Log: log}
 }
 
-func (c *ClusterTypeChecker) DetermineClusterType() string {
+// DetermineClusterType Returns the flavor of the cluster. Most flavors are told apart by the API groups and the version
+// of the API server, both come from the discovery cache of the K8sUtil. An error is returned if the API groups cannot
+// be read, many flavors cannot be told apart without them.
+func (c *ClusterTypeChecker) DetermineClusterType() (string, error) {
+	apiGroups, err := c.getAPIGroups()
+	if err != nil {
+		return "", err
+	}
+	c.apiGroups = apiGroups
+
 	if c.isAnthosFlavor() {
-		return FlavorAnthos
+		return FlavorAnthos, nil
 	}
 
 	if c.isOpenshiftFlavor() {
-		return FlavorOpenShift
+		return c.determineOpenshiftType(), nil
+	}
+
+	// EKS Anywhere check must come before the EKS check, its versions have the eks suffix as well
+	if c.isEKSAFlavor() {
+		return FlavorEKSA, nil
+	}
+
+	// K3s check must come before the RKE2 check, K3s serves the k3s API as well
+	if c.isK3sFlavor() {
+		return FlavorK3s, nil
 	}
 
 	// RKE2 check must come before the RKE check
 	if c.isRKE2Flavor() {
-		return FlavorRKE2
+		return FlavorRKE2, nil
 	}
 
 	if c.isRKEFlavor() {
-		return FlavorRKE
+		return FlavorRKE, nil
 	}
 
 	if c.isTanzuFlavor() {
-		return FlavorTanzu
+		return FlavorTanzu, nil
 	}
 
 	if c.isGKEFlavor() {
-		return FlavorGKE
+		return FlavorGKE, nil
 	}
 
 	if c.isAKSFlavor() {
-		return FlavorAKS
+		return FlavorAKS, nil
 	}
 
 	if c.isEKSFlavor() {
-		return FlavorEKS
+		return FlavorEKS, nil
 	}
 
-	return FlavorKubernetes
-}
+	// The remaining flavors only differ from upstream kubernetes in how their nodes are set up
+	if node := c.getNode(); node != nil {
+		if c.isKindFlavor(node) {
+			return FlavorKind, nil
+		}
 
-// isOpenshiftFlavor - tries to hit an api registered by the openshift operator
-// https://confluence.ngage.netapp.com/display/POLARIS/OpenShift+Questions
-func (c *ClusterTypeChecker) isOpenshiftFlavor() bool {
+		if c.isMicroK8sFlavor(node) {
+			return FlavorMicroK8s, nil
+		}
 
-	_, err := c.getOpenshiftVersion()
-	if err != nil {
-		if errors.IsNotFound(err) {
-			return false
+		if c.isCharmedFlavor(node) {
+			return FlavorCharmed, nil
 		}
-		c.Log.Error(err, "error requesting for openshift version endpoint")
-		return false
 	}
 
-	return true
+	return FlavorKubernetes, nil
 }
 
-func (c *ClusterTypeChecker) getOpenshiftVersion() (string, error) {
+// determineOpenshiftType - tells the managed OpenShift services and OKD apart from OpenShift Container Platform
+func (c *ClusterTypeChecker) determineOpenshiftType() string {
+	if c.isAPIGroupServed("aro.openshift.io") {
+		return FlavorARO
+	}
+
+	if c.isAPIGroupServed("upgrade.managed.openshift.io") {
+		return FlavorROSA
+	}
+
+	// OKD versions carry the okd build, e.g. 4.14.0-0.okd-2024-01-26-175629
+	version, err := c.GetOpenshiftVersion()
+	if err == nil && strings.Contains(strings.ToLower(version), FlavorOKD) {
+		return FlavorOKD
+	}
+
+	return FlavorOpenShift
+}
+
+// isOpenshiftFlavor - checks for the presence of the API registered by the openshift operator
+// https://confluence.ngage.netapp.com/display/POLARIS/OpenShift+Questions
+func (c *ClusterTypeChecker) isOpenshiftFlavor() bool {
+	return c.isAPIGroupServed("config.openshift.io")
+}
+
+// GetOpenshiftVersion returns the version of the openshift-apiserver cluster operator
+func (c *ClusterTypeChecker) GetOpenshiftVersion() (string, error) {
 	// struct modeling only required information present in openshift API response
 	var openshiftVersionSchema struct {
 		Status struct {
@@ -122,54 +188,27 @@ func (c *ClusterTypeChecker) getOpenshiftVersion() (string, error) {
 
 // isRKEFlavor - checks for the presence of the rancher API
 func (c *ClusterTypeChecker) isRKEFlavor() bool {
-	// Querying the base URL will return a list of supported API versions. If we wanted to we could
-	// parse the response, extract the latest version and use it to query cluster CRs to ensure one
-	// actually exists, but for now the presence of the API is sufficient for determining it is Rancher
-	_, err := c.K8sUtil.RESTGet("apis/management.cattle.io")
-
-	if err != nil {
-		if errors.IsNotFound(err) {
-			return false
-		}
-		c.Log.Error(err, "error querying the rancher API")
-		return false
-	}
-	return true
+	// The presence of the API is sufficient for determining it is Rancher, a cluster CR does not need to exist
+	return c.isAPIGroupServed("management.cattle.io")
 }
 
 // isRKE2Flavor - checks for the presence of the RKE2 base: k3s API
 func (c *ClusterTypeChecker) isRKE2Flavor() bool {
-	_, err := c.K8sUtil.RESTGet("apis/k3s.cattle.io")
-
-	if err != nil {
-		if errors.IsNotFound(err) {
-			return false
-		}
-		c.Log.Error(err, "error querying the RKE2 API")
-		return false
-	}
-	return true
+	return c.isAPIGroupServed("k3s.cattle.io")
 }
 
 // isTanzuFlavor - checks for the presence of the tanzu API
 func (c *ClusterTypeChecker) isTanzuFlavor() bool {
-	_, err := c.K8sUtil.RESTGet("apis/core.antrea.tanzu.vmware.com")
-
-	if err != nil {
-		if errors.IsNotFound(err) {
-			return false
-		}
-		c.Log.Error(err, "error querying the TKG API")
-		return false
-	}
-	return true
+	return c.isAPIGroupServed("core.antrea.tanzu.vmware.com")
 }
 
 // isAKSFlavor - checks cluster roles for AKS service resource
 // https://docs.microsoft.com/en-us/azure/aks/concepts-identity#clusterrolebinding
 func (c *ClusterTypeChecker) isAKSFlavor() bool {
 	const aksService = "aks-service"
-	aksRoleBinding, err := c.K8sUtil.K8sClientset().RbacV1().ClusterRoles().Get(context.Background(), aksService, metav1.GetOptions{})
+	ctx, cancel := NewRequestContext()
+	defer cancel()
+	aksRoleBinding, err := c.K8sUtil.K8sClientset().RbacV1().ClusterRoles().Get(ctx, aksService, metav1.GetOptions{})
 
 	if err != nil {
 		if errors.IsNotFound(err) {
@@ -204,14 +243,85 @@ func (c *ClusterTypeChecker) isEKSFlavor() bool {
 
 // isAnthosFlavor - checks for the presence of the Anthos API
 func (c *ClusterTypeChecker) isAnthosFlavor() bool {
-	_, err := c.K8sUtil.RESTGet("apis/anthos.gke.io")
+	return c.isAPIGroupServed("anthos.gke.io")
+}
 
+// isEKSAFlavor - checks for the presence of the EKS Anywhere API or its system namespace
+func (c *ClusterTypeChecker) isEKSAFlavor() bool {
+	if c.isAPIGroupServed("anywhere.eks.amazonaws.com") {
+		return true
+	}
+	// Workload clusters only have the system namespace, it is looked up on EKS versions only
+	if !c.isEKSFlavor() {
+		return false
+	}
+	ctx, cancel := NewRequestContext()
+	defer cancel()
+	_, err := c.K8sUtil.K8sClientset().CoreV1().Namespaces().Get(ctx, "eksa-system", metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		c.Log.Error(err, "Unable to get the EKS Anywhere namespace")
+	}
+	return err == nil
+}
+
+// isK3sFlavor - checks for 'k3s' in the server git version
+// i.e. "gitVersion": "v1.27.4+k3s1",
+func (c *ClusterTypeChecker) isK3sFlavor() bool {
+	version, err := c.K8sUtil.VersionGet()
 	if err != nil {
-		if errors.IsNotFound(err) {
-			return false
-		}
-		c.Log.Error(err, "error querying the Anthos API")
 		return false
 	}
-	return true
+
+	return strings.Contains(strings.ToLower(version), "+"+FlavorK3s)
+}
+
+// isKindFlavor - checks for the kind provider ID of the node
+// i.e. "providerID": "kind://docker/kind/kind-control-plane"
+func (c *ClusterTypeChecker) isKindFlavor(node *corev1.Node) bool {
+	return strings.HasPrefix(node.Spec.ProviderID, "kind://")
+}
+
+// isMicroK8sFlavor - checks for the cluster label MicroK8s sets on its nodes
+func (c *ClusterTypeChecker) isMicroK8sFlavor(node *corev1.Node) bool {
+	return node.Labels["microk8s.io/cluster"] == "true"
+}
+
+// isCharmedFlavor - checks for the application label juju sets on the nodes of Charmed Kubernetes
+func (c *ClusterTypeChecker) isCharmedFlavor(node *corev1.Node) bool {
+	_, ok := node.Labels["juju-application"]
+	return ok
+}
+
+// getNode - returns a node of the cluster, or nil if it cannot be listed
+func (c *ClusterTypeChecker) getNode() *corev1.Node {
+	ctx, cancel := NewRequestContext()
+	defer cancel()
+	nodes, err := c.K8sUtil.K8sClientset().CoreV1().Nodes().List(ctx, metav1.ListOptions{Limit: 1})
+	if err != nil {
+		c.Log.Error(err, "Unable to list nodes")
+		return nil
+	}
+	if len(nodes.Items) == 0 {
+		return nil
+	}
+	return &nodes.Items[0]
+}
+
+// getAPIGroups - returns the set of API groups served by the cluster
+func (c *ClusterTypeChecker) getAPIGroups() (map[string]bool, error) {
+	groups, err := c.K8sUtil.APIGroupsGet()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the API groups: %w", err)
+	}
+
+	apiGroups := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		apiGroups[group] = true
+	}
+	return apiGroups, nil
+}
+
+// isAPIGroupServed - returns true if the cluster serves the given API group
+func (c *ClusterTypeChecker) isAPIGroupServed(group string) bool {
+	return c.apiGroups[group]
 }
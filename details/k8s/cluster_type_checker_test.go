@@ -6,8 +6,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 
@@ -25,10 +27,16 @@ func createHandler(t *testing.T) (k8s.ClusterTypeCheckerInterface, *mocks.K8sUti
 	return clusterTypeChecker, k8sUtil, mockInterface
 }
 
+func assertFlavor(t *testing.T, expected string, clusterTypeChecker k8s.ClusterTypeCheckerInterface) {
+	flavor, err := clusterTypeChecker.DetermineClusterType()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, flavor)
+}
+
 func TestDetermineClusterType(t *testing.T) {
 	t.Run("AKS", func(t *testing.T) {
 		clusterTypeChecker, k8sUtil, k8sInterface := createHandler(t)
-		k8sUtil.On("RESTGet", mock.Anything).Return(nil, errors.New("testing"))
+		k8sUtil.On("APIGroupsGet").Return([]string{"apps"}, nil)
 		k8sUtil.On("VersionGet").Return("1.1", nil)
 		clusterRole := &v1.ClusterRole{
 			ObjectMeta: metav1.ObjectMeta{
@@ -39,68 +47,196 @@ func TestDetermineClusterType(t *testing.T) {
 		_, err := k8sInterface.RbacV1().ClusterRoles().Create(ctx, clusterRole, metav1.CreateOptions{})
 		assert.NoError(t, err)
 
-		assert.Equal(t, k8s.FlavorAKS, clusterTypeChecker.DetermineClusterType())
+		assertFlavor(t, k8s.FlavorAKS, clusterTypeChecker)
 	})
 
 	t.Run("Anthos", func(t *testing.T) {
 		clusterTypeChecker, k8sUtil, _ := createHandler(t)
-		k8sUtil.On("RESTGet", "apis/anthos.gke.io").Return(make([]byte, 0), nil)
+		k8sUtil.On("APIGroupsGet").Return([]string{"apps", "anthos.gke.io"}, nil)
 		k8sUtil.On("VersionGet").Return("1.1", nil)
 
-		assert.Equal(t, k8s.FlavorAnthos, clusterTypeChecker.DetermineClusterType())
+		assertFlavor(t, k8s.FlavorAnthos, clusterTypeChecker)
 	})
 
 	t.Run("RKE", func(t *testing.T) {
 		clusterTypeChecker, k8sUtil, _ := createHandler(t)
-		k8sUtil.On("RESTGet", "apis/management.cattle.io").Return(make([]byte, 0), nil)
-		k8sUtil.On("RESTGet", mock.Anything).Return(nil, errors.New("testing"))
+		k8sUtil.On("APIGroupsGet").Return([]string{"apps", "management.cattle.io"}, nil)
 		k8sUtil.On("VersionGet").Return("1.1", nil)
 
-		assert.Equal(t, k8s.FlavorRKE, clusterTypeChecker.DetermineClusterType())
+		assertFlavor(t, k8s.FlavorRKE, clusterTypeChecker)
 	})
 
 	t.Run("RKE2", func(t *testing.T) {
 		clusterTypeChecker, k8sUtil, _ := createHandler(t)
-		k8sUtil.On("RESTGet", "apis/k3s.cattle.io").Return(make([]byte, 0), nil)
-		k8sUtil.On("RESTGet", mock.Anything).Return(nil, errors.New("testing"))
+		k8sUtil.On("APIGroupsGet").Return([]string{"apps", "k3s.cattle.io"}, nil)
 		k8sUtil.On("VersionGet").Return("1.1", nil)
 
-		assert.Equal(t, k8s.FlavorRKE2, clusterTypeChecker.DetermineClusterType())
+		assertFlavor(t, k8s.FlavorRKE2, clusterTypeChecker)
 	})
 
 	t.Run("Tanzu", func(t *testing.T) {
 		clusterTypeChecker, k8sUtil, _ := createHandler(t)
-		k8sUtil.On("RESTGet", "apis/core.antrea.tanzu.vmware.com").Return(make([]byte, 0), nil)
-		k8sUtil.On("RESTGet", mock.Anything).Return(nil, errors.New("testing"))
+		k8sUtil.On("APIGroupsGet").Return([]string{"apps", "core.antrea.tanzu.vmware.com"}, nil)
 		k8sUtil.On("VersionGet").Return("1.1", nil)
 
-		assert.Equal(t, k8s.FlavorTanzu, clusterTypeChecker.DetermineClusterType())
+		assertFlavor(t, k8s.FlavorTanzu, clusterTypeChecker)
 	})
 
 	t.Run("GKE", func(t *testing.T) {
 		clusterTypeChecker, k8sUtil, _ := createHandler(t)
-		k8sUtil.On("RESTGet", mock.Anything).Return(nil, errors.New("testing"))
+		k8sUtil.On("APIGroupsGet").Return([]string{"apps"}, nil)
 		k8sUtil.On("VersionGet").Return("1.1-gke", nil)
 
-		assert.Equal(t, k8s.FlavorGKE, clusterTypeChecker.DetermineClusterType())
+		assertFlavor(t, k8s.FlavorGKE, clusterTypeChecker)
 	})
 
 	t.Run("EKS", func(t *testing.T) {
 		clusterTypeChecker, k8sUtil, _ := createHandler(t)
-		k8sUtil.On("RESTGet", mock.Anything).Return(nil, errors.New("testing"))
+		k8sUtil.On("APIGroupsGet").Return([]string{"apps"}, nil)
 		k8sUtil.On("VersionGet").Return("v1.28.5-eks-5e0fdde", nil)
 
-		assert.Equal(t, k8s.FlavorEKS, clusterTypeChecker.DetermineClusterType())
+		assertFlavor(t, k8s.FlavorEKS, clusterTypeChecker)
 	})
 
 	t.Run("openshift", func(t *testing.T) {
 		clusterTypeChecker, k8sUtil, _ := createHandler(t)
 		versionBytes := []byte(`{"Status": {"Versions": [{"Name": "openshift-apiserver", "Version": "1.0.0"}]}}`)
 
+		k8sUtil.On("APIGroupsGet").Return([]string{"apps", "config.openshift.io"}, nil)
 		k8sUtil.On("RESTGet", "apis/config.openshift.io/v1/clusteroperators/openshift-apiserver").Return(versionBytes, nil)
-		k8sUtil.On("RESTGet", mock.Anything).Return(nil, errors.New("testing"))
 		k8sUtil.On("VersionGet").Return("1.1", nil)
 
-		assert.Equal(t, k8s.FlavorOpenShift, clusterTypeChecker.DetermineClusterType())
+		assertFlavor(t, k8s.FlavorOpenShift, clusterTypeChecker)
 	})
+
+	t.Run("APIGroupsNotReadable", func(t *testing.T) {
+		clusterTypeChecker, k8sUtil, _ := createHandler(t)
+		k8sUtil.On("APIGroupsGet").Return(nil, errors.New("testing"))
+
+		// OpenShift must not be mistaken for kubernetes, so no flavor is guessed
+		flavor, err := clusterTypeChecker.DetermineClusterType()
+		assert.ErrorContains(t, err, "testing")
+		assert.Empty(t, flavor)
+		k8sUtil.AssertNotCalled(t, "VersionGet")
+		k8sUtil.AssertNotCalled(t, "RESTGet", mock.Anything)
+	})
+}
+
+func TestDetermineClusterTypeFlavors(t *testing.T) {
+	openshiftVersion := func(version string) []byte {
+		return []byte(`{"Status": {"Versions": [{"Name": "openshift-apiserver", "Version": "` + version + `"}]}}`)
+	}
+
+	tests := []struct {
+		name             string
+		groups           []string
+		openshiftVersion []byte
+		version          string
+		objects          []runtime.Object
+		expected         string
+	}{
+		{
+			name:     "DetermineClusterType__Kubernetes",
+			version:  "v1.29.1",
+			objects:  []runtime.Object{&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-0"}}},
+			expected: k8s.FlavorKubernetes,
+		},
+		{
+			name:     "DetermineClusterType__NoNodes",
+			version:  "v1.29.1",
+			expected: k8s.FlavorKubernetes,
+		},
+		{
+			name:     "DetermineClusterType__K3s",
+			groups:   []string{"k3s.cattle.io"},
+			version:  "v1.27.4+k3s1",
+			expected: k8s.FlavorK3s,
+		},
+		{
+			name:     "DetermineClusterType__RKE2NotK3s",
+			groups:   []string{"k3s.cattle.io"},
+			version:  "v1.27.4+rke2r1",
+			expected: k8s.FlavorRKE2,
+		},
+		{
+			name:    "DetermineClusterType__Kind",
+			version: "v1.29.2",
+			objects: []runtime.Object{&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "kind-control-plane"},
+				Spec: corev1.NodeSpec{ProviderID: "kind://docker/kind/kind-control-plane"}}},
+			expected: k8s.FlavorKind,
+		},
+		{
+			name:    "DetermineClusterType__MicroK8s",
+			version: "v1.28.3",
+			objects: []runtime.Object{&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "microk8s",
+				Labels: map[string]string{"microk8s.io/cluster": "true"}}}},
+			expected: k8s.FlavorMicroK8s,
+		},
+		{
+			name:    "DetermineClusterType__Charmed",
+			version: "v1.28.3",
+			objects: []runtime.Object{&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "juju-0",
+				Labels: map[string]string{"juju-application": "kubernetes-worker"}}}},
+			expected: k8s.FlavorCharmed,
+		},
+		{
+			name:             "DetermineClusterType__OpenShift",
+			groups:           []string{"config.openshift.io"},
+			openshiftVersion: openshiftVersion("4.14.8"),
+			version:          "v1.27.8+b5c2b6e",
+			expected:         k8s.FlavorOpenShift,
+		},
+		{
+			name:             "DetermineClusterType__OKD",
+			groups:           []string{"config.openshift.io"},
+			openshiftVersion: openshiftVersion("4.14.0-0.okd-2024-01-26-175629"),
+			version:          "v1.27.8+b5c2b6e",
+			expected:         k8s.FlavorOKD,
+		},
+		{
+			name:             "DetermineClusterType__ROSA",
+			groups:           []string{"config.openshift.io", "upgrade.managed.openshift.io"},
+			openshiftVersion: openshiftVersion("4.14.8"),
+			version:          "v1.27.8+b5c2b6e",
+			expected:         k8s.FlavorROSA,
+		},
+		{
+			name:             "DetermineClusterType__ARO",
+			groups:           []string{"config.openshift.io", "aro.openshift.io"},
+			openshiftVersion: openshiftVersion("4.13.23"),
+			version:          "v1.26.9+636f2be",
+			expected:         k8s.FlavorARO,
+		},
+		{
+			name:     "DetermineClusterType__EKSAnywhere",
+			groups:   []string{"anywhere.eks.amazonaws.com"},
+			version:  "v1.27.4-eks-cedffd4",
+			expected: k8s.FlavorEKSA,
+		},
+		{
+			name:     "DetermineClusterType__EKSAnywhereWorkloadCluster",
+			version:  "v1.27.4-eks-cedffd4",
+			objects:  []runtime.Object{&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "eksa-system"}}},
+			expected: k8s.FlavorEKSA,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sUtil := new(mocks.K8sUtilInterface)
+			k8sUtil.On("K8sClientset").Return(fake.NewSimpleClientset(tt.objects...))
+			k8sUtil.On("APIGroupsGet").Return(append([]string{"apps", "batch"}, tt.groups...), nil)
+			k8sUtil.On("VersionGet").Return(tt.version, nil)
+			if tt.openshiftVersion != nil {
+				k8sUtil.On("RESTGet", "apis/config.openshift.io/v1/clusteroperators/openshift-apiserver").Return(tt.openshiftVersion, nil)
+			}
+			clusterTypeChecker := k8s.NewClusterTypeChecker(k8sUtil, testutil.CreateLoggerForTesting(t))
+
+			assertFlavor(t, tt.expected, clusterTypeChecker)
+			// Only the OpenShift version is read through a REST call of its own
+			if tt.openshiftVersion == nil {
+				k8sUtil.AssertNotCalled(t, "RESTGet", mock.Anything)
+			}
+		})
+	}
 }
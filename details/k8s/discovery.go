@@ -0,0 +1,130 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// RequestTimeout bounds the requests made to detect the cluster, so a slow API server cannot hang a reconcile
+	RequestTimeout = 10 * time.Second
+	// DiscoveryTTL is how long the API groups and the version of the API server are cached
+	DiscoveryTTL = 5 * time.Minute
+)
+
+// discoveryCaches holds a DiscoveryCache per clientset, K8sUtils are created every reconcile while the clientset
+// lives as long as the operator
+var discoveryCaches sync.Map
+
+// DiscoveryCache caches the API groups and the version served by the API server, each is fetched once per TTL
+type DiscoveryCache struct {
+	discovery discovery.DiscoveryInterface
+	ttl       time.Duration
+
+	mutex         sync.Mutex
+	groups        []string
+	groupsExpiry  time.Time
+	version       string
+	versionExpiry time.Time
+}
+
+func NewDiscoveryCache(discoveryClient discovery.DiscoveryInterface, ttl time.Duration) *DiscoveryCache {
+	return &DiscoveryCache{discovery: discoveryClient, ttl: ttl}
+}
+
+// NewRequestContext Returns a context that expires after the RequestTimeout
+func NewRequestContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), RequestTimeout)
+}
+
+// sharedDiscoveryCache Returns the DiscoveryCache of the clientset, creating it on first use
+func sharedDiscoveryCache(clientset kubernetes.Interface) *DiscoveryCache {
+	if cache, ok := discoveryCaches.Load(clientset); ok {
+		return cache.(*DiscoveryCache)
+	}
+	cache, _ := discoveryCaches.LoadOrStore(clientset, NewDiscoveryCache(clientset.Discovery(), DiscoveryTTL))
+	return cache.(*DiscoveryCache)
+}
+
+// APIGroups Returns the names of the API groups served by the API server, the core group is not included
+func (d *DiscoveryCache) APIGroups(ctx context.Context) ([]string, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.groups != nil && time.Now().Before(d.groupsExpiry) {
+		return d.groups, nil
+	}
+
+	groupList, err := d.fetchGroups(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting server groups")
+	}
+
+	groups := make([]string, 0, len(groupList.Groups))
+	for _, group := range groupList.Groups {
+		groups = append(groups, group.Name)
+	}
+	d.groups = groups
+	d.groupsExpiry = time.Now().Add(d.ttl)
+	return d.groups, nil
+}
+
+// ServerVersion Returns the git version of the API server, e.g. v1.29.1
+func (d *DiscoveryCache) ServerVersion(ctx context.Context) (string, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.version != "" && time.Now().Before(d.versionExpiry) {
+		return d.version, nil
+	}
+
+	versionInfo, err := d.fetchVersion(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "error getting server version")
+	}
+
+	d.version = versionInfo.GitVersion
+	d.versionExpiry = time.Now().Add(d.ttl)
+	return d.version, nil
+}
+
+func (d *DiscoveryCache) fetchGroups(ctx context.Context) (*metav1.APIGroupList, error) {
+	restClient := d.discovery.RESTClient()
+	if restClient == nil {
+		// Discovery clients without a REST client, e.g. the fake one, can only be asked without a context
+		return d.discovery.ServerGroups()
+	}
+	groupList := &metav1.APIGroupList{}
+	return groupList, getJSON(ctx, restClient, "/apis", groupList)
+}
+
+func (d *DiscoveryCache) fetchVersion(ctx context.Context) (*version.Info, error) {
+	restClient := d.discovery.RESTClient()
+	if restClient == nil {
+		return d.discovery.ServerVersion()
+	}
+	versionInfo := &version.Info{}
+	return versionInfo, getJSON(ctx, restClient, "/version", versionInfo)
+}
+
+// getJSON Reads the JSON served at the path into result, the request is bound by the context
+func getJSON(ctx context.Context, restClient rest.Interface, path string, result interface{}) error {
+	body, err := restClient.Get().AbsPath(path).Do(ctx).Raw()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, result)
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package k8s_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/NetApp-Polaris/astra-connector-operator/details/k8s"
+	testutil "github.com/NetApp-Polaris/astra-connector-operator/test/test-util"
+)
+
+func createFakeDiscovery() (*fake.Clientset, *fakediscovery.FakeDiscovery) {
+	clientset := fake.NewSimpleClientset()
+	discovery := clientset.Discovery().(*fakediscovery.FakeDiscovery)
+	discovery.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "apps/v1"},
+		{GroupVersion: "config.openshift.io/v1"},
+	}
+	discovery.FakedServerVersion = &version.Info{GitVersion: "v1.27.8+b5c2b6e"}
+	return clientset, discovery
+}
+
+func countDiscoveryActions(clientset *fake.Clientset, resource string) int {
+	count := 0
+	for _, action := range clientset.Actions() {
+		if action.GetResource().Resource == resource {
+			count++
+		}
+	}
+	return count
+}
+
+func TestDiscoveryCache(t *testing.T) {
+	t.Run("DiscoveryCache__FetchedOnceWithinTTL", func(t *testing.T) {
+		clientset, discovery := createFakeDiscovery()
+		cache := k8s.NewDiscoveryCache(discovery, time.Hour)
+
+		for i := 0; i < 3; i++ {
+			groups, err := cache.APIGroups(ctx)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, []string{"apps", "config.openshift.io"}, groups)
+
+			serverVersion, err := cache.ServerVersion(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, "v1.27.8+b5c2b6e", serverVersion)
+		}
+
+		assert.Equal(t, 1, countDiscoveryActions(clientset, "group"))
+		assert.Equal(t, 1, countDiscoveryActions(clientset, "version"))
+	})
+
+	t.Run("DiscoveryCache__RefreshedAfterTTL", func(t *testing.T) {
+		clientset, discovery := createFakeDiscovery()
+		cache := k8s.NewDiscoveryCache(discovery, 0)
+
+		_, _ = cache.APIGroups(ctx)
+		discovery.Resources = append(discovery.Resources, &metav1.APIResourceList{GroupVersion: "aro.openshift.io/v1"})
+		groups, err := cache.APIGroups(ctx)
+
+		assert.NoError(t, err)
+		assert.Contains(t, groups, "aro.openshift.io")
+		assert.Equal(t, 2, countDiscoveryActions(clientset, "group"))
+	})
+
+	t.Run("DiscoveryCache__ErrorsAreNotCached", func(t *testing.T) {
+		clientset, discovery := createFakeDiscovery()
+		cache := k8s.NewDiscoveryCache(discovery, time.Hour)
+
+		clientset.PrependReactor("get", "version", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("connection refused")
+		})
+		_, err := cache.ServerVersion(ctx)
+		assert.ErrorContains(t, err, "error getting server version")
+
+		clientset.ReactionChain = clientset.ReactionChain[1:]
+		serverVersion, err := cache.ServerVersion(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "v1.27.8+b5c2b6e", serverVersion)
+	})
+}
+
+func TestK8sUtilDiscovery(t *testing.T) {
+	clientset, _ := createFakeDiscovery()
+	log := testutil.CreateLoggerForTesting(t)
+
+	// Every K8sUtil of the clientset shares its cache
+	for i := 0; i < 2; i++ {
+		k8sUtil := k8s.NewK8sUtil(testutil.CreateFakeClient(), clientset, log)
+
+		serverVersion, err := k8sUtil.VersionGet()
+		assert.NoError(t, err)
+		assert.Equal(t, "v1.27.8+b5c2b6e", serverVersion)
+
+		groups, err := k8sUtil.APIGroupsGet()
+		assert.NoError(t, err)
+		assert.Contains(t, groups, "config.openshift.io")
+	}
+
+	assert.Equal(t, 1, countDiscoveryActions(clientset, "version"))
+	assert.Equal(t, 1, countDiscoveryActions(clientset, "group"))
+}
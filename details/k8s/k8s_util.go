@@ -7,17 +7,17 @@ package k8s
 import (
 	"context"
 	"github.com/go-logr/logr"
-	"github.com/pkg/errors"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	"github.com/NetApp-Polaris/astra-connector-operator/common"
 	"github.com/NetApp-Polaris/astra-connector-operator/util"
 )
 
@@ -29,8 +29,10 @@ type K8sUtil struct {
 
 type K8sUtilInterface interface {
 	CreateOrUpdateResource(context.Context, client.Object, client.Object, controllerutil.MutateFn) (string, error)
+	ApplyResource(context.Context, client.Object, client.Object) (string, error)
 	DeleteResource(context.Context, client.Object) error
 	VersionGet() (string, error)
+	APIGroupsGet() ([]string, error)
 	IsCRDInstalled(string) bool
 	RESTGet(string) ([]byte, error)
 	K8sClientset() kubernetes.Interface
@@ -50,6 +52,10 @@ func (r *K8sUtil) CreateOrUpdateResource(ctx context.Context, resource client.Ob
 		}
 	}
 
+	if !isNamespaceScoped(resource) && !util.IsNil(owner) {
+		f = withOwnerLabels(resource, owner, f)
+	}
+
 	// Use the ctrl.CreateOrUpdate function with the MutateFn function
 	operationResult, err := ctrl.CreateOrUpdate(ctx, r.Client, resource, f)
 	return string(operationResult), err
@@ -59,10 +65,33 @@ func (r *K8sUtil) DeleteResource(ctx context.Context, resource client.Object) er
 	return r.Client.Delete(ctx, resource)
 }
 
+// withOwnerLabels wraps the MutateFn so the owner labels are set on create and restored on every update
+func withOwnerLabels(resource client.Object, owner client.Object, f controllerutil.MutateFn) controllerutil.MutateFn {
+	return func() error {
+		if f != nil {
+			if err := f(); err != nil {
+				return err
+			}
+		}
+
+		labels := resource.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[common.OwnerNameLabel] = owner.GetName()
+		labels[common.OwnerNamespaceLabel] = owner.GetNamespace()
+		resource.SetLabels(labels)
+		return nil
+	}
+}
+
 func isNamespaceScoped(obj client.Object) bool {
 	switch obj.(type) {
 	case *rbacv1.ClusterRole, *rbacv1.ClusterRoleBinding:
 		return false
+	case *unstructured.Unstructured:
+		// Kinds without a Go type, e.g. the OpenShift SecurityContextConstraints, are built without a namespace when cluster scoped
+		return obj.GetNamespace() != ""
 	default:
 		return true
 	}
@@ -70,20 +99,23 @@ func isNamespaceScoped(obj client.Object) bool {
 
 // VersionGet returns the server version of the k8s cluster.
 func (r *K8sUtil) VersionGet() (string, error) {
-	restConfig, err := ctrl.GetConfig()
-	if err != nil {
-		return "", errors.Wrap(err, "error getting kubeconfig")
-	}
-	dClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
-	if err != nil {
-		return "", errors.Wrap(err, "error creating discovery client")
-	}
-	versionInfo, err := dClient.ServerVersion()
+	ctx, cancel := NewRequestContext()
+	defer cancel()
+
+	version, err := sharedDiscoveryCache(r.Interface).ServerVersion(ctx)
 	if err != nil {
-		return "", errors.Wrap(err, "error getting server version")
+		return "", err
 	}
-	r.Log.V(3).Info("versionInfo", "versionInfo", versionInfo)
-	return versionInfo.GitVersion, nil
+	r.Log.V(3).Info("versionInfo", "gitVersion", version)
+	return version, nil
+}
+
+// APIGroupsGet returns the names of the API groups served by the k8s cluster.
+func (r *K8sUtil) APIGroupsGet() ([]string, error) {
+	ctx, cancel := NewRequestContext()
+	defer cancel()
+
+	return sharedDiscoveryCache(r.Interface).APIGroups(ctx)
 }
 
 // IsCRDInstalled returns the server version of the k8s cluster.
@@ -108,7 +140,10 @@ func (r *K8sUtil) IsCRDInstalled(crdName string) bool {
 
 // RESTGet Makes a GET request on the K8s Rest Client and returns the raw byte array
 func (r *K8sUtil) RESTGet(path string) ([]byte, error) {
-	return r.Interface.Discovery().RESTClient().Get().AbsPath(path).DoRaw(context.TODO())
+	ctx, cancel := NewRequestContext()
+	defer cancel()
+
+	return r.Interface.Discovery().RESTClient().Get().AbsPath(path).DoRaw(ctx)
 }
 
 // K8sClientset Returns the k8s Clientset
@@ -12,11 +12,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/NetApp-Polaris/astra-connector-operator/common"
 	"github.com/NetApp-Polaris/astra-connector-operator/details/k8s"
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
 	testutil "github.com/NetApp-Polaris/astra-connector-operator/test/test-util"
 )
 
@@ -127,6 +130,67 @@ func TestCreateOrUpdateResource(t *testing.T) {
 		assert.Equal(t, clusterRole.Name, updatedClusterRole.Name)
 		assert.Equal(t, clusterRole.Rules, updatedClusterRole.Rules)
 	})
+
+	t.Run("cluster scoped resource is labeled with its owner", func(t *testing.T) {
+		owner := &v1.AstraConnector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "astra-connector",
+				Namespace: "astra-connector",
+			},
+		}
+		clusterRole := &rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-owned-cluster-role",
+			},
+		}
+
+		resultString, err := k8sUtil.CreateOrUpdateResource(ctx, clusterRole, owner, model.NonMutateFn)
+		assert.NoError(t, err)
+		assert.Equal(t, "created", resultString)
+
+		var createdClusterRole rbacv1.ClusterRole
+		err = k8sClient.Get(ctx, client.ObjectKey{Name: clusterRole.Name}, &createdClusterRole)
+		assert.NoError(t, err)
+		assert.Equal(t, owner.Name, createdClusterRole.Labels[common.OwnerNameLabel])
+		assert.Equal(t, owner.Namespace, createdClusterRole.Labels[common.OwnerNamespaceLabel])
+
+		// Labels removed by someone else are restored on the next update
+		createdClusterRole.Labels = nil
+		assert.NoError(t, k8sClient.Update(ctx, &createdClusterRole))
+
+		clusterRole = &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "test-owned-cluster-role"}}
+		resultString, err = k8sUtil.CreateOrUpdateResource(ctx, clusterRole, owner, model.NonMutateFn)
+		assert.NoError(t, err)
+		assert.Equal(t, "updated", resultString)
+
+		var updatedClusterRole rbacv1.ClusterRole
+		err = k8sClient.Get(ctx, client.ObjectKey{Name: clusterRole.Name}, &updatedClusterRole)
+		assert.NoError(t, err)
+		assert.Equal(t, owner.Name, updatedClusterRole.Labels[common.OwnerNameLabel])
+	})
+
+	t.Run("unstructured resource without a namespace is cluster scoped", func(t *testing.T) {
+		owner := &v1.AstraConnector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "astra-connector",
+				Namespace: "astra-connector",
+			},
+		}
+		scc := &unstructured.Unstructured{}
+		scc.SetGroupVersionKind(model.SecurityContextConstraintsGVK)
+		scc.SetName("test-scc")
+
+		resultString, err := k8sUtil.CreateOrUpdateResource(ctx, scc, owner, model.NonMutateFn)
+		assert.NoError(t, err)
+		assert.Equal(t, "created", resultString)
+
+		createdSCC := &unstructured.Unstructured{}
+		createdSCC.SetGroupVersionKind(model.SecurityContextConstraintsGVK)
+		err = k8sClient.Get(ctx, client.ObjectKey{Name: "test-scc"}, createdSCC)
+		assert.NoError(t, err)
+		assert.Empty(t, createdSCC.GetOwnerReferences())
+		assert.Equal(t, owner.Name, createdSCC.GetLabels()[common.OwnerNameLabel])
+	})
 }
 
 func TestDeleteResource(t *testing.T) {
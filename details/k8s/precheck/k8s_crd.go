@@ -1,12 +1,33 @@
 package precheck
 
 import (
+	"strings"
+
 	"github.com/pkg/errors"
 )
 
-func (p *PrecheckClient) RunK8sCRDCheck() error {
-	if !p.k8sUtil.IsCRDInstalled("volumesnapshotclasses.snapshot.storage.k8s.io") {
-		return errors.New("Could not find volumesnapshotclasses CRD")
+// CheckSnapshotCRD is the name of the check that the volume snapshot CRDs are installed
+const CheckSnapshotCRD = "snapshot-crd"
+
+// snapshotAPIPath is the supported version of the volume snapshot API
+const snapshotAPIPath = "apis/snapshot.storage.k8s.io/v1"
+
+var snapshotCRDs = []string{"volumesnapshotclasses", "volumesnapshots", "volumesnapshotcontents"}
+
+// RunK8sCRDCheck checks that the volume snapshot CRDs are installed and serve the supported version
+func (p *PrecheckClient) RunK8sCRDCheck(_ SetWarning) error {
+	var missing []string
+	for _, crd := range snapshotCRDs {
+		if !p.k8sUtil.IsCRDInstalled(crd + ".snapshot.storage.k8s.io") {
+			missing = append(missing, crd)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.Errorf("Could not find %s CRD", strings.Join(missing, ", "))
+	}
+
+	if _, err := p.k8sUtil.RESTGet(snapshotAPIPath); err != nil {
+		return errors.Wrap(err, "The volume snapshot CRDs do not serve snapshot.storage.k8s.io/v1")
 	}
 	return nil
 }
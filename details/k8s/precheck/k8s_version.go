@@ -3,52 +3,105 @@
 package precheck
 
 import (
-	"errors"
 	"fmt"
 
 	semver "github.com/hashicorp/go-version"
-)
+	"github.com/pkg/errors"
 
-const (
-	MinKubernetesVersion = "1.24.0"
-	MaxKubernetesVersion = "1.29.99"
+	"github.com/NetApp-Polaris/astra-connector-operator/app/conf"
+	"github.com/NetApp-Polaris/astra-connector-operator/details/k8s"
 )
 
-func (p *PrecheckClient) RunK8sVersionCheck() error {
+// CheckK8sVersion is the name of the check of the kubernetes version of the cluster
+const CheckK8sVersion = "k8s-version"
+
+// RunK8sVersionCheck checks the kubernetes version against the window of conf.Config for the flavor of the cluster.
+// A version newer than the tested ones but not newer than the max of the window is only a warning.
+func (p *PrecheckClient) RunK8sVersionCheck(setWarning SetWarning) error {
 	versionString, err := p.k8sUtil.VersionGet()
 	if err != nil {
 		p.log.Error(err, "failed to get k8s version of host cluster")
 		return err
 	}
 
-	k8sVersion, err := semver.NewSemver(versionString)
+	k8sVersion, err := minorVersion(versionString)
 	if err != nil {
 		p.log.Error(err, "failed to parse k8s version string", "version string", versionString)
 		return err
 	}
 
-	if warning := isSupported(*k8sVersion); warning == nil {
-		p.log.Info("detected valid k8s version")
-		return nil
-	} else {
-		return errors.New(*warning)
+	window, err := p.k8sVersionWindow()
+	if err != nil {
+		return err
+	}
+	minVersion, err := minorVersion(window.Min)
+	if err != nil {
+		return errors.Wrap(err, "invalid minimum kubernetes version")
+	}
+	maxTestedVersion, err := minorVersion(window.MaxTested)
+	if err != nil {
+		return errors.Wrap(err, "invalid maximum tested kubernetes version")
 	}
-}
 
-func isSupported(k8sVersion semver.Version) *string {
-	minVersion := semver.Must(semver.NewSemver(MinKubernetesVersion))
-	maxVersion := semver.Must(semver.NewSemver(MaxKubernetesVersion))
+	// Versions newer than MaxTested up to Max are allowed with a warning, there is no such band if Max is empty
+	maxAllowed := window.MaxTested
+	maxVersion := maxTestedVersion
+	if window.Max != "" {
+		maxAllowed = window.Max
+		maxVersion, err = minorVersion(window.Max)
+		if err != nil {
+			return errors.Wrap(err, "invalid maximum kubernetes version")
+		}
+	}
 
-	if k8sVersion.GreaterThanOrEqual(minVersion) && k8sVersion.LessThan(maxVersion) {
-		return nil
+	if k8sVersion.LessThan(minVersion) || k8sVersion.GreaterThan(maxVersion) {
+		return fmt.Errorf(
+			"Cluster isn't running a supported version of kubernetes. "+
+				"Use a supported kubernetes version in the following range: %v to %v.",
+			window.Min,
+			maxAllowed,
+		)
 	}
 
-	message := fmt.Sprintf(
-		"Cluster isn't running a supported version of kubernetes. "+
-			"Use a supported kubernetes version in the following range: %v to %v.",
-		MinKubernetesVersion,
-		MaxKubernetesVersion,
-	)
+	if k8sVersion.GreaterThan(maxTestedVersion) {
+		return setWarning(fmt.Sprintf(
+			"Kubernetes version %v has not been tested. The tested versions are %v to %v.",
+			versionString,
+			window.Min,
+			window.MaxTested,
+		))
+	}
+
+	p.log.Info("detected valid k8s version")
+	return nil
+}
 
-	return &message
+// k8sVersionWindow returns the window of kubernetes versions for the flavor of the cluster, the flavor is only
+// determined when the window is overridden for a flavor and it was not set with SetClusterFlavor
+func (p *PrecheckClient) k8sVersionWindow() (conf.KubernetesVersionWindow, error) {
+	kubernetesVersions := conf.Config.KubernetesVersions()
+	if !kubernetesVersions.HasFlavors() {
+		return kubernetesVersions.Window(k8s.FlavorKubernetes), nil
+	}
+	flavor := p.clusterFlavor
+	if flavor == "" {
+		var err error
+		flavor, err = k8s.NewClusterTypeChecker(p.k8sUtil, p.log).DetermineClusterType()
+		if err != nil {
+			return conf.KubernetesVersionWindow{}, errors.Wrap(err, "unable to determine the cluster flavor")
+		}
+	}
+	p.log.Info("Using the kubernetes version window of the cluster flavor", "flavor", flavor)
+	return kubernetesVersions.Window(flavor), nil
+}
+
+// minorVersion returns the major and minor of a version, dropping the patch and the suffixes added by
+// distributions, e.g. v1.27.3-eks-a5565ad or v1.26.5+k3s1
+func minorVersion(version string) (*semver.Version, error) {
+	parsed, err := semver.NewSemver(version)
+	if err != nil {
+		return nil, err
+	}
+	segments := parsed.Segments()
+	return semver.NewSemver(fmt.Sprintf("%d.%d", segments[0], segments[1]))
 }
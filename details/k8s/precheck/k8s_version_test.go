@@ -11,9 +11,11 @@ import (
 
 func TestIsSupported(t *testing.T) {
 	testCases := []struct {
-		name          string
-		k8sVersion    string
-		expectedValid bool
+		name            string
+		k8sVersion      string
+		expectedValid   bool
+		expectedWarning bool
+		expectedError   string
 	}{
 		{
 			name:          "Minimum supported version",
@@ -21,9 +23,9 @@ func TestIsSupported(t *testing.T) {
 			expectedValid: true,
 		},
 		{
-			name:          "Maximum supported version",
+			name:          "Maximum tested version",
 			k8sVersion:    "1.29.99",
-			expectedValid: false,
+			expectedValid: true,
 		},
 		{
 			name:          "Within supported range",
@@ -34,10 +36,51 @@ func TestIsSupported(t *testing.T) {
 			name:          "Below supported range",
 			k8sVersion:    "1.23.0",
 			expectedValid: false,
+			expectedError: "Cluster isn't running a supported version of kubernetes. " +
+				"Use a supported kubernetes version in the following range: 1.24 to 1.31.",
+		},
+		{
+			name:            "Untested but allowed",
+			k8sVersion:      "1.30.1",
+			expectedValid:   true,
+			expectedWarning: true,
+		},
+		{
+			name:            "Maximum allowed version",
+			k8sVersion:      "1.31.0",
+			expectedValid:   true,
+			expectedWarning: true,
 		},
 		{
-			name:          "Above supported range",
-			k8sVersion:    "1.30.1",
+			name:          "Above allowed range",
+			k8sVersion:    "1.32.0",
+			expectedValid: false,
+			expectedError: "Cluster isn't running a supported version of kubernetes. " +
+				"Use a supported kubernetes version in the following range: 1.24 to 1.31.",
+		},
+		{
+			name:          "EKS suffix",
+			k8sVersion:    "v1.24.0-eks-a5565ad",
+			expectedValid: true,
+		},
+		{
+			name:          "GKE suffix",
+			k8sVersion:    "v1.27.8-gke.1067004",
+			expectedValid: true,
+		},
+		{
+			name:          "K3s suffix",
+			k8sVersion:    "v1.29.1+k3s2",
+			expectedValid: true,
+		},
+		{
+			name:          "Suffix below supported range",
+			k8sVersion:    "v1.23.17-eks-a5565ad",
+			expectedValid: false,
+		},
+		{
+			name:          "Invalid version",
+			k8sVersion:    "latest",
 			expectedValid: false,
 		},
 	}
@@ -46,16 +89,24 @@ func TestIsSupported(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			log := testutil.CreateLoggerForTesting(t)
 			mockK8sUtil := mocks.NewK8sUtilInterface(t)
+			mockSetWarning := mocks.NewSetWarning(t)
 			precheckClient := precheck.NewPrecheckClient(log, mockK8sUtil)
 
 			mockK8sUtil.On("VersionGet").Return(tc.k8sVersion, nil)
+			if tc.expectedWarning {
+				mockSetWarning.On("Execute", "Kubernetes version "+tc.k8sVersion+" has not been tested. "+
+					"The tested versions are 1.24 to 1.29.").Return(nil)
+			}
 
-			err := precheckClient.RunK8sVersionCheck()
+			err := precheckClient.RunK8sVersionCheck(mockSetWarning.Execute)
 			if tc.expectedValid {
 				assert.Nil(t, err, "We expected no error ")
 			} else {
 				assert.NotNil(t, err)
 			}
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			}
 
 		})
 	}
@@ -3,16 +3,62 @@
 package precheck
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/go-logr/logr"
 
+	"github.com/NetApp-Polaris/astra-connector-operator/app/metrics"
 	"github.com/NetApp-Polaris/astra-connector-operator/details/k8s"
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
 )
 
+// SetWarning records an issue found by a check that does not fail it, a check that passes with warnings
+// is reported as failed with severity warning
 type SetWarning func(message string) error
 
+// CheckFunc runs a check, a returned error fails the check with the severity of the check
+type CheckFunc func(p *PrecheckClient, setWarning SetWarning) error
+
+// Check is a named pre-check, the name is used in spec.precheck.skip and in the status of the AstraConnector
+type Check struct {
+	Name     string
+	Severity string
+	Run      CheckFunc
+}
+
+var registry []Check
+
+func init() {
+	Register(Check{Name: CheckK8sVersion, Severity: v1.PrecheckSeverityError, Run: (*PrecheckClient).RunK8sVersionCheck})
+	Register(Check{Name: CheckSnapshotCRD, Severity: v1.PrecheckSeverityError, Run: (*PrecheckClient).RunK8sCRDCheck})
+	Register(Check{Name: CheckDefaultStorageClass, Severity: v1.PrecheckSeverityWarning, Run: (*PrecheckClient).RunDefaultStorageClassCheck})
+	Register(Check{Name: CheckSnapshotClass, Severity: v1.PrecheckSeverityWarning, Run: (*PrecheckClient).RunSnapshotClassCheck})
+	Register(Check{Name: CheckSnapshotController, Severity: v1.PrecheckSeverityWarning, Run: (*PrecheckClient).RunSnapshotControllerCheck})
+	Register(Check{Name: CheckTrident, Severity: v1.PrecheckSeverityWarning, Run: (*PrecheckClient).RunTridentCheck})
+}
+
+// Register adds a check to the checks run by PrecheckClient.Run, the name of each check must be unique
+func Register(check Check) {
+	for _, registered := range registry {
+		if registered.Name == check.Name {
+			panic(fmt.Sprintf("pre-check %s is already registered", check.Name))
+		}
+	}
+	registry = append(registry, check)
+}
+
+// RegisteredChecks returns the registered checks in the order they run
+func RegisteredChecks() []Check {
+	return slices.Clone(registry)
+}
+
 type PrecheckClient struct {
-	k8sUtil k8s.K8sUtilInterface
-	log     logr.Logger
+	k8sUtil       k8s.K8sUtilInterface
+	log           logr.Logger
+	trident       *v1.TridentStatus
+	clusterFlavor string
 }
 
 func NewPrecheckClient(log logr.Logger, k8sUtil k8s.K8sUtilInterface) *PrecheckClient {
@@ -22,17 +68,79 @@ func NewPrecheckClient(log logr.Logger, k8sUtil k8s.K8sUtilInterface) *PrecheckC
 	}
 }
 
-func (p *PrecheckClient) Run() []error {
-	var errList []error
-	err := p.RunK8sVersionCheck()
-	if err != nil {
-		errList = append(errList, err)
+// SetClusterFlavor sets the flavor of the cluster already detected by the caller, so the checks do not detect it again
+func (p *PrecheckClient) SetClusterFlavor(flavor string) {
+	p.clusterFlavor = flavor
+}
+
+// Run runs the registered checks except the ones named in skip
+func (p *PrecheckClient) Run(skip []string) []v1.PrecheckResult {
+	return p.RunChecks(RegisteredChecks(), skip)
+}
+
+// RunChecks runs the given checks in order except the ones named in skip, and returns the result of each
+func (p *PrecheckClient) RunChecks(checks []Check, skip []string) []v1.PrecheckResult {
+	for _, name := range skip {
+		if !slices.ContainsFunc(checks, func(check Check) bool { return check.Name == name }) {
+			p.log.Info("Ignoring unknown pre-check in skip list", "check", name)
+		}
+	}
+
+	var results []v1.PrecheckResult
+	for _, check := range checks {
+		if slices.Contains(skip, check.Name) {
+			p.log.Info("Skipping pre-check", "check", check.Name)
+			results = append(results, v1.PrecheckResult{
+				Name:     check.Name,
+				Severity: check.Severity,
+				Result:   v1.PrecheckResultSkipped,
+			})
+			continue
+		}
+		results = append(results, p.runCheck(check))
+	}
+	return results
+}
+
+func (p *PrecheckClient) runCheck(check Check) v1.PrecheckResult {
+	result := v1.PrecheckResult{
+		Name:     check.Name,
+		Severity: check.Severity,
+		Result:   v1.PrecheckResultPassed,
 	}
 
-	err = p.RunK8sCRDCheck()
-	if err != nil {
-		errList = append(errList, err)
+	var warnings []string
+	setWarning := func(message string) error {
+		warnings = append(warnings, message)
+		return nil
 	}
 
-	return errList
+	if err := check.Run(p, setWarning); err != nil {
+		result.Result = v1.PrecheckResultFailed
+		result.Message = err.Error()
+	} else if len(warnings) > 0 {
+		result.Result = v1.PrecheckResultFailed
+		result.Message = strings.Join(warnings, " ")
+		// A warning never raises the severity of an info check
+		if check.Severity == v1.PrecheckSeverityError {
+			result.Severity = v1.PrecheckSeverityWarning
+		}
+	}
+
+	if result.Result == v1.PrecheckResultFailed {
+		metrics.PrecheckFailures.WithLabelValues(check.Name, result.Severity).Inc()
+		p.log.Info("Pre-check failed", "check", check.Name, "severity", result.Severity, "message", result.Message)
+	}
+	return result
+}
+
+// Failures returns the failed results of the given severity as "name: message"
+func Failures(results []v1.PrecheckResult, severity string) []string {
+	var failures []string
+	for _, result := range results {
+		if result.Result == v1.PrecheckResultFailed && result.Severity == severity {
+			failures = append(failures, fmt.Sprintf("%s: %s", result.Name, result.Message))
+		}
+	}
+	return failures
 }
@@ -0,0 +1,129 @@
+package precheck_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/NetApp-Polaris/astra-connector-operator/details/k8s/precheck"
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+	"github.com/NetApp-Polaris/astra-connector-operator/mocks"
+	testutil "github.com/NetApp-Polaris/astra-connector-operator/test/test-util"
+)
+
+func newTestCheck(name, severity string, err error, warnings ...string) precheck.Check {
+	return precheck.Check{
+		Name:     name,
+		Severity: severity,
+		Run: func(_ *precheck.PrecheckClient, setWarning precheck.SetWarning) error {
+			for _, warning := range warnings {
+				_ = setWarning(warning)
+			}
+			return err
+		},
+	}
+}
+
+func TestRunChecks(t *testing.T) {
+	tests := []struct {
+		name     string
+		check    precheck.Check
+		skip     []string
+		expected v1.PrecheckResult
+	}{
+		{
+			name:     "RunChecks__Passed",
+			check:    newTestCheck("passing", v1.PrecheckSeverityError, nil),
+			expected: v1.PrecheckResult{Name: "passing", Severity: v1.PrecheckSeverityError, Result: v1.PrecheckResultPassed},
+		},
+		{
+			name:  "RunChecks__FailedWithSeverityOfCheck",
+			check: newTestCheck("failing", v1.PrecheckSeverityError, errors.New("not installed")),
+			expected: v1.PrecheckResult{Name: "failing", Severity: v1.PrecheckSeverityError, Result: v1.PrecheckResultFailed,
+				Message: "not installed"},
+		},
+		{
+			name:  "RunChecks__FailedInfo",
+			check: newTestCheck("info", v1.PrecheckSeverityInfo, errors.New("not recommended")),
+			expected: v1.PrecheckResult{Name: "info", Severity: v1.PrecheckSeverityInfo, Result: v1.PrecheckResultFailed,
+				Message: "not recommended"},
+		},
+		{
+			name:  "RunChecks__WarningLowersSeverity",
+			check: newTestCheck("warning", v1.PrecheckSeverityError, nil, "untested.", "really."),
+			expected: v1.PrecheckResult{Name: "warning", Severity: v1.PrecheckSeverityWarning, Result: v1.PrecheckResultFailed,
+				Message: "untested. really."},
+		},
+		{
+			name:  "RunChecks__WarningKeepsInfoSeverity",
+			check: newTestCheck("info-warning", v1.PrecheckSeverityInfo, nil, "untested."),
+			expected: v1.PrecheckResult{Name: "info-warning", Severity: v1.PrecheckSeverityInfo, Result: v1.PrecheckResultFailed,
+				Message: "untested."},
+		},
+		{
+			name:     "RunChecks__Skipped",
+			check:    newTestCheck("skipped", v1.PrecheckSeverityError, errors.New("not installed")),
+			skip:     []string{"unknown", "skipped"},
+			expected: v1.PrecheckResult{Name: "skipped", Severity: v1.PrecheckSeverityError, Result: v1.PrecheckResultSkipped},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			precheckClient := precheck.NewPrecheckClient(testutil.CreateLoggerForTesting(t), mocks.NewK8sUtilInterface(t))
+
+			results := precheckClient.RunChecks([]precheck.Check{tt.check}, tt.skip)
+			assert.Equal(t, []v1.PrecheckResult{tt.expected}, results)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Run("Run__WarningDoesNotFail", func(t *testing.T) {
+		precheckClient, k8sUtil := createStorageK8sUtil(t,
+			newStorageClass("ontap-nas", true),
+			&storagev1.CSIDriver{ObjectMeta: metav1.ObjectMeta{Name: "csi.trident.netapp.io"}},
+			newSnapshotControllerPod(map[string]string{"app": "snapshot-controller"}, corev1.PodRunning),
+		)
+		k8sUtil.On("VersionGet").Return("1.30.1", nil)
+		k8sUtil.On("IsCRDInstalled", mock.Anything).Return(true)
+		k8sUtil.On("RESTGet", "apis/snapshot.storage.k8s.io/v1").Return([]byte("{}"), nil)
+		k8sUtil.On("RESTGet", snapshotClassesPath).Return([]byte(`{"items":[{"driver":"csi.trident.netapp.io"}]}`), nil)
+		k8sUtil.On("RESTGet", tridentOrchestratorsPath).Return(nil, errTridentNotFound)
+		k8sUtil.On("RESTGet", allTridentVersionsPath).Return(nil, errTridentNotFound)
+
+		results := precheckClient.Run(nil)
+		assert.Len(t, results, len(precheck.RegisteredChecks()))
+		assert.Empty(t, precheck.Failures(results, v1.PrecheckSeverityError))
+		assert.Equal(t, []string{"k8s-version: Kubernetes version 1.30.1 has not been tested. The tested versions are 1.24 to 1.29."},
+			precheck.Failures(results, v1.PrecheckSeverityWarning))
+	})
+
+	t.Run("Run__SkipByName", func(t *testing.T) {
+		precheckClient, k8sUtil := createStorageK8sUtil(t)
+		k8sUtil.On("IsCRDInstalled", "volumesnapshotclasses.snapshot.storage.k8s.io").Return(false)
+		k8sUtil.On("IsCRDInstalled", mock.Anything).Return(true)
+
+		results := precheckClient.Run([]string{precheck.CheckK8sVersion, precheck.CheckDefaultStorageClass,
+			precheck.CheckSnapshotClass, precheck.CheckSnapshotController, precheck.CheckTrident})
+		assert.Equal(t, v1.PrecheckResultSkipped, results[0].Result)
+		assert.Equal(t, []string{"snapshot-crd: Could not find volumesnapshotclasses CRD"},
+			precheck.Failures(results, v1.PrecheckSeverityError))
+		assert.Empty(t, precheck.Failures(results, v1.PrecheckSeverityWarning))
+	})
+}
+
+func TestRegister(t *testing.T) {
+	t.Run("Register__DuplicateNamePanics", func(t *testing.T) {
+		before := precheck.RegisteredChecks()
+		assert.Panics(t, func() {
+			precheck.Register(newTestCheck(precheck.CheckK8sVersion, v1.PrecheckSeverityError, nil))
+		})
+		assert.Len(t, precheck.RegisteredChecks(), len(before))
+	})
+}
@@ -0,0 +1,140 @@
+// Copyright 2024 NetApp, Inc. All Rights Reserved.
+
+package precheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/NetApp-Polaris/astra-connector-operator/details/k8s"
+)
+
+// Names of the storage and snapshot checks
+const (
+	CheckDefaultStorageClass = "default-storage-class"
+	CheckSnapshotClass       = "snapshot-class"
+	CheckSnapshotController  = "snapshot-controller"
+)
+
+const (
+	defaultStorageClassAnnotation     = "storageclass.kubernetes.io/is-default-class"
+	betaDefaultStorageClassAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
+	volumeSnapshotClassesPath         = "apis/snapshot.storage.k8s.io/v1/volumesnapshotclasses"
+)
+
+// snapshotControllerSelectors select the pods of the snapshot-controller of the external-snapshotter,
+// older releases of its manifests use the app label
+var snapshotControllerSelectors = []string{
+	"app.kubernetes.io/name=snapshot-controller",
+	"app=snapshot-controller",
+}
+
+// managedSnapshotControllerFlavors run the snapshot-controller themselves, in the control plane or with their own
+// labels, so it is working whenever the volume snapshot API is served
+var managedSnapshotControllerFlavors = []string{
+	k8s.FlavorGKE, k8s.FlavorAKS, k8s.FlavorEKS, k8s.FlavorOpenShift, k8s.FlavorOKD, k8s.FlavorROSA, k8s.FlavorARO,
+}
+
+// volumeSnapshotClassList is the part of a list of snapshot.storage.k8s.io/v1 VolumeSnapshotClasses read by the checks
+type volumeSnapshotClassList struct {
+	Items []struct {
+		Driver string `json:"driver"`
+	} `json:"items"`
+}
+
+// RunDefaultStorageClassCheck checks that exactly one StorageClass is annotated as the default
+func (p *PrecheckClient) RunDefaultStorageClassCheck(setWarning SetWarning) error {
+	storageClasses, err := p.k8sUtil.K8sClientset().StorageV1().StorageClasses().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list storage classes")
+	}
+
+	var defaults []string
+	for _, storageClass := range storageClasses.Items {
+		if storageClass.Annotations[defaultStorageClassAnnotation] == "true" ||
+			storageClass.Annotations[betaDefaultStorageClassAnnotation] == "true" {
+			defaults = append(defaults, storageClass.Name)
+		}
+	}
+
+	switch len(defaults) {
+	case 0:
+		return errors.New("No default StorageClass found. Annotate a StorageClass with " + defaultStorageClassAnnotation + "=true.")
+	case 1:
+		return nil
+	default:
+		return setWarning(fmt.Sprintf("Multiple default StorageClasses found: %s.", strings.Join(defaults, ", ")))
+	}
+}
+
+// RunSnapshotClassCheck checks that a VolumeSnapshotClass uses the driver of a CSIDriver installed on the cluster
+func (p *PrecheckClient) RunSnapshotClassCheck(_ SetWarning) error {
+	body, err := p.k8sUtil.RESTGet(volumeSnapshotClassesPath)
+	if err != nil {
+		return errors.Wrap(err, "failed to list volume snapshot classes")
+	}
+	snapshotClasses := &volumeSnapshotClassList{}
+	if err = json.Unmarshal(body, snapshotClasses); err != nil {
+		return errors.Wrap(err, "failed to parse volume snapshot classes")
+	}
+	if len(snapshotClasses.Items) == 0 {
+		return errors.New("No VolumeSnapshotClass found.")
+	}
+
+	csiDrivers, err := p.k8sUtil.K8sClientset().StorageV1().CSIDrivers().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list CSI drivers")
+	}
+	var driverNames []string
+	for _, csiDriver := range csiDrivers.Items {
+		driverNames = append(driverNames, csiDriver.Name)
+	}
+
+	var snapshotDrivers []string
+	for _, snapshotClass := range snapshotClasses.Items {
+		if slices.Contains(driverNames, snapshotClass.Driver) {
+			return nil
+		}
+		snapshotDrivers = append(snapshotDrivers, snapshotClass.Driver)
+	}
+
+	return fmt.Errorf("No VolumeSnapshotClass uses an installed CSI driver. "+
+		"The VolumeSnapshotClasses use %s, the installed CSI drivers are %s.",
+		strings.Join(snapshotDrivers, ", "), strings.Join(driverNames, ", "))
+}
+
+// RunSnapshotControllerCheck checks that a pod of the snapshot-controller is running. On the managed flavors, which run
+// it themselves, the volume snapshot API being served is enough.
+func (p *PrecheckClient) RunSnapshotControllerCheck(_ SetWarning) error {
+	if slices.Contains(managedSnapshotControllerFlavors, p.clusterFlavor) {
+		if _, err := p.k8sUtil.RESTGet(snapshotAPIPath); err != nil {
+			return errors.Wrapf(err, "The volume snapshot API is not served on %s, volume snapshots will not be taken", p.clusterFlavor)
+		}
+		return nil
+	}
+
+	for _, selector := range snapshotControllerSelectors {
+		// A single running pod is enough, so only that one is listed
+		pods, err := p.k8sUtil.K8sClientset().CoreV1().Pods(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{
+			LabelSelector: selector,
+			FieldSelector: "status.phase=" + string(corev1.PodRunning),
+			Limit:         1,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to list snapshot-controller pods")
+		}
+		for _, pod := range pods.Items {
+			if pod.Status.Phase == corev1.PodRunning {
+				return nil
+			}
+		}
+	}
+	return errors.New("No running snapshot-controller found, volume snapshots will not be taken.")
+}
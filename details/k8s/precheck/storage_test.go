@@ -0,0 +1,229 @@
+package precheck_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/NetApp-Polaris/astra-connector-operator/details/k8s/precheck"
+	"github.com/NetApp-Polaris/astra-connector-operator/mocks"
+	testutil "github.com/NetApp-Polaris/astra-connector-operator/test/test-util"
+)
+
+const snapshotClassesPath = "apis/snapshot.storage.k8s.io/v1/volumesnapshotclasses"
+
+func createStorageK8sUtil(t *testing.T, objects ...runtime.Object) (*precheck.PrecheckClient, *mocks.K8sUtilInterface) {
+	k8sUtil := mocks.NewK8sUtilInterface(t)
+	k8sUtil.On("K8sClientset").Return(fake.NewSimpleClientset(objects...)).Maybe()
+	return precheck.NewPrecheckClient(testutil.CreateLoggerForTesting(t), k8sUtil), k8sUtil
+}
+
+func newStorageClass(name string, isDefault bool) *storagev1.StorageClass {
+	storageClass := &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: name}, Provisioner: "csi.trident.netapp.io"}
+	if isDefault {
+		storageClass.Annotations = map[string]string{"storageclass.kubernetes.io/is-default-class": "true"}
+	}
+	return storageClass
+}
+
+func newSnapshotControllerPod(labels map[string]string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "snapshot-controller-0", Namespace: "kube-system", Labels: labels},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestRunDefaultStorageClassCheck(t *testing.T) {
+	tests := []struct {
+		name            string
+		objects         []runtime.Object
+		expectedErr     bool
+		expectedWarning string
+	}{
+		{
+			name:    "RunDefaultStorageClassCheck__Default",
+			objects: []runtime.Object{newStorageClass("standard", false), newStorageClass("ontap-nas", true)},
+		},
+		{
+			name: "RunDefaultStorageClassCheck__BetaAnnotation",
+			objects: []runtime.Object{&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "standard",
+				Annotations: map[string]string{"storageclass.beta.kubernetes.io/is-default-class": "true"}}}},
+		},
+		{
+			name:        "RunDefaultStorageClassCheck__NoDefault",
+			objects:     []runtime.Object{newStorageClass("standard", false)},
+			expectedErr: true,
+		},
+		{
+			name:            "RunDefaultStorageClassCheck__MultipleDefaults",
+			objects:         []runtime.Object{newStorageClass("ontap-nas", true), newStorageClass("standard", true)},
+			expectedWarning: "Multiple default StorageClasses found: ontap-nas, standard.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			precheckClient, _ := createStorageK8sUtil(t, tt.objects...)
+			setWarning := mocks.NewSetWarning(t)
+			if tt.expectedWarning != "" {
+				setWarning.On("Execute", tt.expectedWarning).Return(nil)
+			}
+
+			err := precheckClient.RunDefaultStorageClassCheck(setWarning.Execute)
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRunSnapshotClassCheck(t *testing.T) {
+	tridentDriver := &storagev1.CSIDriver{ObjectMeta: metav1.ObjectMeta{Name: "csi.trident.netapp.io"}}
+	tests := []struct {
+		name          string
+		body          string
+		restErr       error
+		objects       []runtime.Object
+		expectedError string
+	}{
+		{
+			name:    "RunSnapshotClassCheck__MatchingDriver",
+			body:    `{"items":[{"metadata":{"name":"ebs"},"driver":"ebs.csi.aws.com"},{"metadata":{"name":"trident"},"driver":"csi.trident.netapp.io"}]}`,
+			objects: []runtime.Object{tridentDriver},
+		},
+		{
+			name:          "RunSnapshotClassCheck__NoMatchingDriver",
+			body:          `{"items":[{"metadata":{"name":"ebs"},"driver":"ebs.csi.aws.com"}]}`,
+			objects:       []runtime.Object{tridentDriver},
+			expectedError: "No VolumeSnapshotClass uses an installed CSI driver. The VolumeSnapshotClasses use ebs.csi.aws.com, the installed CSI drivers are csi.trident.netapp.io.",
+		},
+		{
+			name:          "RunSnapshotClassCheck__NoSnapshotClass",
+			body:          `{"items":[]}`,
+			expectedError: "No VolumeSnapshotClass found.",
+		},
+		{
+			name:          "RunSnapshotClassCheck__ListFailed",
+			restErr:       errors.New("not found"),
+			expectedError: "failed to list volume snapshot classes: not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			precheckClient, k8sUtil := createStorageK8sUtil(t, tt.objects...)
+			k8sUtil.On("RESTGet", snapshotClassesPath).Return([]byte(tt.body), tt.restErr)
+
+			err := precheckClient.RunSnapshotClassCheck(nil)
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestRunSnapshotControllerCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		objects     []runtime.Object
+		expectedErr bool
+	}{
+		{
+			name:    "RunSnapshotControllerCheck__Running",
+			objects: []runtime.Object{newSnapshotControllerPod(map[string]string{"app.kubernetes.io/name": "snapshot-controller"}, corev1.PodRunning)},
+		},
+		{
+			name:    "RunSnapshotControllerCheck__LegacyLabel",
+			objects: []runtime.Object{newSnapshotControllerPod(map[string]string{"app": "snapshot-controller"}, corev1.PodRunning)},
+		},
+		{
+			name:        "RunSnapshotControllerCheck__NotRunning",
+			objects:     []runtime.Object{newSnapshotControllerPod(map[string]string{"app": "snapshot-controller"}, corev1.PodPending)},
+			expectedErr: true,
+		},
+		{
+			name:        "RunSnapshotControllerCheck__Missing",
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			precheckClient, _ := createStorageK8sUtil(t, tt.objects...)
+
+			err := precheckClient.RunSnapshotControllerCheck(nil)
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRunSnapshotControllerCheckManagedFlavor(t *testing.T) {
+	t.Run("RunSnapshotControllerCheck__ManagedFlavorServesSnapshotAPI", func(t *testing.T) {
+		// No snapshot-controller pod is visible, it runs in the control plane
+		precheckClient, k8sUtil := createStorageK8sUtil(t)
+		precheckClient.SetClusterFlavor("gke")
+		k8sUtil.On("RESTGet", "apis/snapshot.storage.k8s.io/v1").Return([]byte("{}"), nil)
+
+		assert.NoError(t, precheckClient.RunSnapshotControllerCheck(nil))
+		k8sUtil.AssertNotCalled(t, "K8sClientset")
+	})
+
+	t.Run("RunSnapshotControllerCheck__ManagedFlavorWithoutSnapshotAPI", func(t *testing.T) {
+		precheckClient, k8sUtil := createStorageK8sUtil(t)
+		precheckClient.SetClusterFlavor("aks")
+		k8sUtil.On("RESTGet", "apis/snapshot.storage.k8s.io/v1").Return(nil, errors.New("not found"))
+
+		assert.ErrorContains(t, precheckClient.RunSnapshotControllerCheck(nil), "The volume snapshot API is not served on aks")
+	})
+
+	t.Run("RunSnapshotControllerCheck__UnmanagedFlavorNeedsPod", func(t *testing.T) {
+		precheckClient, _ := createStorageK8sUtil(t)
+		precheckClient.SetClusterFlavor("rke2")
+
+		assert.Error(t, precheckClient.RunSnapshotControllerCheck(nil))
+	})
+}
+
+func TestRunK8sCRDCheck(t *testing.T) {
+	t.Run("RunK8sCRDCheck__Installed", func(t *testing.T) {
+		precheckClient, k8sUtil := createStorageK8sUtil(t)
+		k8sUtil.On("IsCRDInstalled", "volumesnapshotclasses.snapshot.storage.k8s.io").Return(true)
+		k8sUtil.On("IsCRDInstalled", "volumesnapshots.snapshot.storage.k8s.io").Return(true)
+		k8sUtil.On("IsCRDInstalled", "volumesnapshotcontents.snapshot.storage.k8s.io").Return(true)
+		k8sUtil.On("RESTGet", "apis/snapshot.storage.k8s.io/v1").Return([]byte("{}"), nil)
+
+		assert.NoError(t, precheckClient.RunK8sCRDCheck(nil))
+	})
+
+	t.Run("RunK8sCRDCheck__Missing", func(t *testing.T) {
+		precheckClient, k8sUtil := createStorageK8sUtil(t)
+		k8sUtil.On("IsCRDInstalled", "volumesnapshotclasses.snapshot.storage.k8s.io").Return(true)
+		k8sUtil.On("IsCRDInstalled", "volumesnapshots.snapshot.storage.k8s.io").Return(false)
+		k8sUtil.On("IsCRDInstalled", "volumesnapshotcontents.snapshot.storage.k8s.io").Return(false)
+
+		assert.EqualError(t, precheckClient.RunK8sCRDCheck(nil), "Could not find volumesnapshots, volumesnapshotcontents CRD")
+	})
+
+	t.Run("RunK8sCRDCheck__UnsupportedVersion", func(t *testing.T) {
+		precheckClient, k8sUtil := createStorageK8sUtil(t)
+		k8sUtil.On("IsCRDInstalled", mock.Anything).Return(true)
+		k8sUtil.On("RESTGet", "apis/snapshot.storage.k8s.io/v1").Return(nil, errors.New("the server could not find the requested resource"))
+
+		assert.ErrorContains(t, precheckClient.RunK8sCRDCheck(nil), "do not serve snapshot.storage.k8s.io/v1")
+	})
+}
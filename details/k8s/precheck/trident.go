@@ -0,0 +1,153 @@
+// Copyright 2024 NetApp, Inc. All Rights Reserved.
+
+package precheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	semver "github.com/hashicorp/go-version"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+)
+
+// CheckTrident is the name of the check of the Trident installation
+const CheckTrident = "trident"
+
+// Range of Trident minor versions supported by the connector, every patch release of them is supported.
+// Astra Control Provisioner requires at least MinTridentVersion.
+const (
+	MinTridentVersion = "23.10"
+	MaxTridentVersion = "24.06"
+)
+
+const (
+	tridentOrchestratorsPath = "apis/trident.netapp.io/v1/tridentorchestrators"
+	tridentVersionsPath      = "apis/trident.netapp.io/v1/tridentversions"
+)
+
+// tridentOrchestratorList is the part of a list of TridentOrchestrators read by the check
+type tridentOrchestratorList struct {
+	Items []struct {
+		Spec struct {
+			Namespace string `json:"namespace"`
+			EnableACP bool   `json:"enableACP"`
+		} `json:"spec"`
+	} `json:"items"`
+}
+
+// tridentVersionList is the part of a list of TridentVersions read by the check
+type tridentVersionList struct {
+	Items []struct {
+		Metadata struct {
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		TridentVersion string `json:"trident_version"`
+	} `json:"items"`
+}
+
+// RunTridentCheck detects the Trident installed by the Trident operator or by tridentctl, and warns when its version
+// is outside the supported range. A cluster without Trident passes, the connector supports other CSI drivers.
+func (p *PrecheckClient) RunTridentCheck(setWarning SetWarning) error {
+	trident, err := p.getTridentStatus()
+	if err != nil {
+		return err
+	}
+	p.trident = trident
+
+	if !trident.Installed {
+		p.log.Info("Trident not found")
+		return nil
+	}
+	p.log.Info("Detected Trident", "version", trident.Version, "namespace", trident.Namespace, "acpEnabled", trident.ACPEnabled)
+
+	tridentVersion, err := minorVersion(trident.Version)
+	if err != nil {
+		return setWarning(fmt.Sprintf("Failed to parse the Trident version %s.", trident.Version))
+	}
+	minVersion := semver.Must(semver.NewSemver(MinTridentVersion))
+	maxVersion := semver.Must(semver.NewSemver(MaxTridentVersion))
+
+	if tridentVersion.LessThan(minVersion) || tridentVersion.GreaterThan(maxVersion) {
+		return setWarning(fmt.Sprintf(
+			"Trident %v is not supported. Use a Trident version in the following range: %v to %v.",
+			trident.Version,
+			MinTridentVersion,
+			MaxTridentVersion,
+		))
+	}
+	return nil
+}
+
+// Trident returns the Trident installation found by the trident check, or nil if the check did not run
+func (p *PrecheckClient) Trident() *v1.TridentStatus {
+	return p.trident
+}
+
+func (p *PrecheckClient) getTridentStatus() (*v1.TridentStatus, error) {
+	body, err := p.k8sUtil.RESTGet(tridentOrchestratorsPath)
+	if apierrors.IsNotFound(err) {
+		// The TridentOrchestrator CRD is only installed by the Trident operator
+		return p.getTridentctlStatus()
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list trident orchestrators")
+	}
+	orchestrators := &tridentOrchestratorList{}
+	if err = json.Unmarshal(body, orchestrators); err != nil {
+		return nil, errors.Wrap(err, "failed to parse trident orchestrators")
+	}
+	if len(orchestrators.Items) == 0 {
+		return p.getTridentctlStatus()
+	}
+
+	orchestrator := orchestrators.Items[0]
+	trident := &v1.TridentStatus{
+		Installed:  true,
+		Namespace:  orchestrator.Spec.Namespace,
+		ACPEnabled: orchestrator.Spec.EnableACP,
+	}
+
+	body, err = p.k8sUtil.RESTGet(fmt.Sprintf("apis/trident.netapp.io/v1/namespaces/%s/tridentversions", trident.Namespace))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list trident versions")
+	}
+	versions := &tridentVersionList{}
+	if err = json.Unmarshal(body, versions); err != nil {
+		return nil, errors.Wrap(err, "failed to parse trident versions")
+	}
+	if len(versions.Items) == 0 {
+		return nil, errors.Errorf("Failed to resolve the version of the Trident in namespace %s", trident.Namespace)
+	}
+	trident.Version = strings.TrimPrefix(versions.Items[0].TridentVersion, "v")
+	return trident, nil
+}
+
+// getTridentctlStatus detects a Trident installed by tridentctl, which has no TridentOrchestrator, by its TridentVersion
+func (p *PrecheckClient) getTridentctlStatus() (*v1.TridentStatus, error) {
+	body, err := p.k8sUtil.RESTGet(tridentVersionsPath)
+	if apierrors.IsNotFound(err) {
+		// The TridentVersion CRD is not installed
+		return &v1.TridentStatus{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list trident versions")
+	}
+	versions := &tridentVersionList{}
+	if err = json.Unmarshal(body, versions); err != nil {
+		return nil, errors.Wrap(err, "failed to parse trident versions")
+	}
+	if len(versions.Items) == 0 {
+		return &v1.TridentStatus{}, nil
+	}
+
+	version := versions.Items[0]
+	return &v1.TridentStatus{
+		Installed: true,
+		Namespace: version.Metadata.Namespace,
+		Version:   strings.TrimPrefix(version.TridentVersion, "v"),
+	}, nil
+}
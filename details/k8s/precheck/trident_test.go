@@ -0,0 +1,134 @@
+package precheck_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/NetApp-Polaris/astra-connector-operator/details/k8s/precheck"
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+	"github.com/NetApp-Polaris/astra-connector-operator/mocks"
+)
+
+const (
+	tridentOrchestratorsPath = "apis/trident.netapp.io/v1/tridentorchestrators"
+	tridentVersionsPath      = "apis/trident.netapp.io/v1/namespaces/trident/tridentversions"
+	allTridentVersionsPath   = "apis/trident.netapp.io/v1/tridentversions"
+	tridentOrchestratorBody  = `{"items":[{"metadata":{"name":"trident"},"spec":{"namespace":"trident","enableACP":true}}]}`
+)
+
+var errTridentNotFound = apierrors.NewNotFound(schema.GroupResource{Group: "trident.netapp.io", Resource: "tridentorchestrators"}, "")
+
+func TestRunTridentCheck(t *testing.T) {
+	tests := []struct {
+		name             string
+		orchestrators    string
+		orchestratorsErr error
+		versions         string
+		allVersions      string
+		allVersionsErr   error
+		expectedWarning  string
+		expectedErr      bool
+		expectedTrident  *v1.TridentStatus
+	}{
+		{
+			name:             "RunTridentCheck__NotInstalled",
+			orchestratorsErr: errTridentNotFound,
+			allVersionsErr:   errTridentNotFound,
+			expectedTrident:  &v1.TridentStatus{},
+		},
+		{
+			name:            "RunTridentCheck__NoOrchestrator",
+			orchestrators:   `{"items":[]}`,
+			allVersions:     `{"items":[]}`,
+			expectedTrident: &v1.TridentStatus{},
+		},
+		{
+			name:             "RunTridentCheck__InstalledByTridentctl",
+			orchestratorsErr: errTridentNotFound,
+			allVersions:      `{"items":[{"metadata":{"name":"trident","namespace":"trident-ns"},"trident_version":"v24.02.0"}]}`,
+			expectedTrident:  &v1.TridentStatus{Installed: true, Namespace: "trident-ns", Version: "24.02.0"},
+		},
+		{
+			name:            "RunTridentCheck__InstalledByTridentctlWithOperatorCRDs",
+			orchestrators:   `{"items":[]}`,
+			allVersions:     `{"items":[{"metadata":{"namespace":"trident"},"trident_version":"23.07.0"}]}`,
+			expectedWarning: "Trident 23.07.0 is not supported. Use a Trident version in the following range: 23.10 to 24.06.",
+			expectedTrident: &v1.TridentStatus{Installed: true, Namespace: "trident", Version: "23.07.0"},
+		},
+		{
+			name:            "RunTridentCheck__Supported",
+			orchestrators:   tridentOrchestratorBody,
+			versions:        `{"items":[{"metadata":{"name":"trident"},"trident_version":"24.02.0"}]}`,
+			expectedTrident: &v1.TridentStatus{Installed: true, Namespace: "trident", Version: "24.02.0", ACPEnabled: true},
+		},
+		{
+			name:            "RunTridentCheck__TooOld",
+			orchestrators:   `{"items":[{"spec":{"namespace":"trident"}}]}`,
+			versions:        `{"items":[{"trident_version":"v23.07.1"}]}`,
+			expectedWarning: "Trident 23.07.1 is not supported. Use a Trident version in the following range: 23.10 to 24.06.",
+			expectedTrident: &v1.TridentStatus{Installed: true, Namespace: "trident", Version: "23.07.1"},
+		},
+		{
+			name:            "RunTridentCheck__TooNew",
+			orchestrators:   tridentOrchestratorBody,
+			versions:        `{"items":[{"trident_version":"24.10.0"}]}`,
+			expectedWarning: "Trident 24.10.0 is not supported. Use a Trident version in the following range: 23.10 to 24.06.",
+			expectedTrident: &v1.TridentStatus{Installed: true, Namespace: "trident", Version: "24.10.0", ACPEnabled: true},
+		},
+		{
+			name:            "RunTridentCheck__PatchOfNewestSupported",
+			orchestrators:   tridentOrchestratorBody,
+			versions:        `{"items":[{"trident_version":"24.06.1"}]}`,
+			expectedTrident: &v1.TridentStatus{Installed: true, Namespace: "trident", Version: "24.06.1", ACPEnabled: true},
+		},
+		{
+			name:          "RunTridentCheck__NoVersion",
+			orchestrators: tridentOrchestratorBody,
+			versions:      `{"items":[]}`,
+			expectedErr:   true,
+		},
+		{
+			name:             "RunTridentCheck__ListFailed",
+			orchestratorsErr: errors.New("connection refused"),
+			expectedErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			precheckClient, k8sUtil := createStorageK8sUtil(t)
+			k8sUtil.On("RESTGet", tridentOrchestratorsPath).Return([]byte(tt.orchestrators), tt.orchestratorsErr)
+			if tt.versions != "" {
+				k8sUtil.On("RESTGet", tridentVersionsPath).Return([]byte(tt.versions), nil)
+			}
+			if tt.allVersions != "" || tt.allVersionsErr != nil {
+				k8sUtil.On("RESTGet", allTridentVersionsPath).Return([]byte(tt.allVersions), tt.allVersionsErr)
+			}
+			setWarning := mocks.NewSetWarning(t)
+			if tt.expectedWarning != "" {
+				setWarning.On("Execute", tt.expectedWarning).Return(nil)
+			}
+
+			err := precheckClient.RunTridentCheck(setWarning.Execute)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, precheckClient.Trident())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedTrident, precheckClient.Trident())
+			}
+		})
+	}
+
+	t.Run("RunTridentCheck__SkippedHasNoStatus", func(t *testing.T) {
+		precheckClient, _ := createStorageK8sUtil(t)
+
+		precheckClient.RunChecks([]precheck.Check{{Name: precheck.CheckTrident, Severity: v1.PrecheckSeverityWarning,
+			Run: (*precheck.PrecheckClient).RunTridentCheck}}, []string{precheck.CheckTrident})
+		assert.Nil(t, precheckClient.Trident())
+	})
+}
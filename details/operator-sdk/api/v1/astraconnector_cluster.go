@@ -0,0 +1,29 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetEnvVars returns the env vars that tell astraconnect the flavor and versions of the cluster,
+// versions that were not detected are left out
+func (c *ClusterStatus) GetEnvVars() []corev1.EnvVar {
+	if c == nil {
+		return nil
+	}
+
+	var envVars []corev1.EnvVar
+	for _, envVar := range []corev1.EnvVar{
+		{Name: "CLUSTER_FLAVOR", Value: c.Flavor},
+		{Name: "KUBERNETES_VERSION", Value: c.KubernetesVersion},
+		{Name: "OPENSHIFT_VERSION", Value: c.OpenShiftVersion},
+	} {
+		if envVar.Value != "" {
+			envVars = append(envVars, envVar)
+		}
+	}
+	return envVars
+}
@@ -0,0 +1,29 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package v1_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+)
+
+func TestClusterStatusGetEnvVars(t *testing.T) {
+	t.Run("GetEnvVars__Nil", func(t *testing.T) {
+		var cluster *v1.ClusterStatus
+		assert.Nil(t, cluster.GetEnvVars())
+	})
+
+	t.Run("GetEnvVars__SkipsEmpty", func(t *testing.T) {
+		cluster := &v1.ClusterStatus{Flavor: "eks", KubernetesVersion: "v1.28.5-eks-5e0fdde"}
+		assert.Equal(t, []corev1.EnvVar{
+			{Name: "CLUSTER_FLAVOR", Value: "eks"},
+			{Name: "KUBERNETES_VERSION", Value: "v1.28.5-eks-5e0fdde"},
+		}, cluster.GetEnvVars())
+	})
+}
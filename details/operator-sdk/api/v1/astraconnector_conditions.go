@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition types reported in AstraConnectorStatus.Conditions
+const (
+	// ConditionReady is True once every component is deployed and the cluster is managed by Astra
+	ConditionReady             = "Ready"
+	ConditionPrecheckPassed    = "PrecheckPassed"
+	ConditionNeptuneDeployed   = "NeptuneDeployed"
+	ConditionConnectorDeployed = "ConnectorDeployed"
+	ConditionClusterManaged    = "ClusterManaged"
+	ConditionASUPConfigured    = "ASUPConfigured"
+	ConditionDeleting          = "Deleting"
+	ConditionUnregistered      = "Unregistered"
+	ConditionPaused            = "Paused"
+)
+
+// Condition reasons, these must be CamelCase as required by metav1.Condition
+const (
+	ReasonReconciling           = "Reconciling"
+	ReasonReconcileSucceeded    = "ReconcileSucceeded"
+	ReasonValidationFailed      = "ValidationFailed"
+	ReasonPrecheckFailed        = "PrecheckFailed"
+	ReasonPrecheckSucceeded     = "PrecheckSucceeded"
+	ReasonPrecheckWarnings      = "PrecheckWarnings"
+	ReasonPrecheckSkipped       = "PrecheckSkipped"
+	ReasonDeployFailed          = "DeployFailed"
+	ReasonDeployInProgress      = "DeployInProgress"
+	ReasonDeploySucceeded       = "DeploySucceeded"
+	ReasonClusterManaged        = "ClusterManaged"
+	ReasonClusterUnmanaged      = "ClusterUnmanaged"
+	ReasonASUPCreateFailed      = "ASUPCreateFailed"
+	ReasonASUPCreated           = "ASUPCreated"
+	ReasonDeletionInProgress    = "DeletionInProgress"
+	ReasonFinalizerAddFailed    = "FinalizerAddFailed"
+	ReasonFinalizerRemoveFailed = "FinalizerRemoveFailed"
+	ReasonUnregisterSucceeded   = "UnregisterSucceeded"
+	ReasonUnregisterFailed      = "UnregisterFailed"
+	ReasonUnregisterTimedOut    = "UnregisterTimedOut"
+	ReasonPaused                = "Paused"
+	ReasonResumed               = "Resumed"
+)
+
+// SetCondition adds or updates the condition of the given type, stamping it with the current generation.
+// The LastTransitionTime is only changed when the condition status changes.
+func (ai *AstraConnector) SetCondition(conditionType string, status metaV1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&ai.Status.Conditions, metaV1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: ai.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// RemoveCondition removes the condition of the given type if it is present
+func (ai *AstraConnector) RemoveCondition(conditionType string) {
+	meta.RemoveStatusCondition(&ai.Status.Conditions, conditionType)
+}
+
+// GetCondition returns the condition of the given type, or nil if it is not present
+func (ai *AstraConnector) GetCondition(conditionType string) *metaV1.Condition {
+	return meta.FindStatusCondition(ai.Status.Conditions, conditionType)
+}
+
+// IsConditionTrue returns true if the condition of the given type is present and set to True
+func (ai *AstraConnector) IsConditionTrue(conditionType string) bool {
+	return meta.IsStatusConditionTrue(ai.Status.Conditions, conditionType)
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package v1_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+)
+
+func TestAstraConnector_SetCondition(t *testing.T) {
+	t.Run("SetCondition__AddsConditionWithGeneration", func(t *testing.T) {
+		ai := &v1.AstraConnector{}
+		ai.Generation = 3
+
+		ai.SetCondition(v1.ConditionReady, metav1.ConditionFalse, v1.ReasonReconciling, "reconciling")
+
+		condition := ai.GetCondition(v1.ConditionReady)
+		assert.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, v1.ReasonReconciling, condition.Reason)
+		assert.Equal(t, "reconciling", condition.Message)
+		assert.Equal(t, int64(3), condition.ObservedGeneration)
+		assert.False(t, ai.IsConditionTrue(v1.ConditionReady))
+	})
+
+	t.Run("SetCondition__UpdatesExistingCondition", func(t *testing.T) {
+		ai := &v1.AstraConnector{}
+		ai.SetCondition(v1.ConditionReady, metav1.ConditionFalse, v1.ReasonReconciling, "reconciling")
+		ai.SetCondition(v1.ConditionReady, metav1.ConditionTrue, v1.ReasonReconcileSucceeded, "done")
+
+		assert.Equal(t, 1, len(ai.Status.Conditions))
+		assert.True(t, ai.IsConditionTrue(v1.ConditionReady))
+		assert.Equal(t, v1.ReasonReconcileSucceeded, ai.GetCondition(v1.ConditionReady).Reason)
+	})
+
+	t.Run("GetCondition__MissingConditionReturnsNil", func(t *testing.T) {
+		ai := &v1.AstraConnector{}
+		assert.Nil(t, ai.GetCondition(v1.ConditionClusterManaged))
+		assert.False(t, ai.IsConditionTrue(v1.ConditionClusterManaged))
+	})
+}
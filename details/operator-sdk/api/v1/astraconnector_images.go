@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package v1
+
+import (
+	"fmt"
+
+	"github.com/NetApp-Polaris/astra-connector-operator/common"
+)
+
+// GetImageRegistry returns the registry the images are pulled from, common.DefaultImageRegistry if the spec sets none
+func (ai *AstraConnector) GetImageRegistry() string {
+	if ai.Spec.ImageRegistry.Name != "" {
+		return ai.Spec.ImageRegistry.Name
+	}
+	return common.DefaultImageRegistry
+}
+
+// GetConnectorImageTag returns spec.astraConnect.image, the tag of the running operator's version if it is empty
+func (ai *AstraConnector) GetConnectorImageTag() string {
+	if ai.Spec.AstraConnect.Image != "" {
+		return ai.Spec.AstraConnect.Image
+	}
+	return common.ConnectorImageTag
+}
+
+// GetConnectorImage returns the Astra Connect image the connector deployer uses
+func (ai *AstraConnector) GetConnectorImage() string {
+	return fmt.Sprintf("%s/astra-connector:%s", ai.GetImageRegistry(), ai.GetConnectorImageTag())
+}
+
+// GetNeptuneImageTag returns spec.neptune.image, the tag of the running operator's version if it is empty
+func (ai *AstraConnector) GetNeptuneImageTag() string {
+	if ai.Spec.Neptune.Image != "" {
+		return ai.Spec.Neptune.Image
+	}
+	return common.NeptuneImageTag
+}
+
+// GetNeptuneImage returns the Neptune controller image the Neptune deployer uses
+func (ai *AstraConnector) GetNeptuneImage() string {
+	return fmt.Sprintf("%s/controller:%s", ai.GetImageRegistry(), ai.GetNeptuneImageTag())
+}
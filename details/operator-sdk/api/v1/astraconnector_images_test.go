@@ -0,0 +1,31 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package v1_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NetApp-Polaris/astra-connector-operator/common"
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+)
+
+func TestAstraConnectorImages(t *testing.T) {
+	t.Run("Images__DefaultsToOperatorTags", func(t *testing.T) {
+		astraConnector := &v1.AstraConnector{}
+		assert.Equal(t, common.DefaultImageRegistry+"/astra-connector:"+common.ConnectorImageTag, astraConnector.GetConnectorImage())
+		assert.Equal(t, common.DefaultImageRegistry+"/controller:"+common.NeptuneImageTag, astraConnector.GetNeptuneImage())
+	})
+
+	t.Run("Images__SpecOverrides", func(t *testing.T) {
+		astraConnector := &v1.AstraConnector{}
+		astraConnector.Spec.ImageRegistry.Name = "registry.example.com/astra"
+		astraConnector.Spec.AstraConnect.Image = "1.0.0"
+		astraConnector.Spec.Neptune.Image = "2.0.0"
+		assert.Equal(t, "registry.example.com/astra/astra-connector:1.0.0", astraConnector.GetConnectorImage())
+		assert.Equal(t, "registry.example.com/astra/controller:2.0.0", astraConnector.GetNeptuneImage())
+	})
+}
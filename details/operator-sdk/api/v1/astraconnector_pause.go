@@ -0,0 +1,14 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package v1
+
+// PausedAnnotation set to "true" stops the operator from applying or deleting anything for the AstraConnector,
+// e.g. while its Deployments are patched by hand during an incident
+const PausedAnnotation = "astra.netapp.io/paused"
+
+// IsPaused Returns true if the reconcile of the AstraConnector is paused by the PausedAnnotation
+func (ai *AstraConnector) IsPaused() bool {
+	return ai.GetAnnotations()[PausedAnnotation] == "true"
+}
@@ -0,0 +1,24 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package v1_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+)
+
+func TestIsPaused(t *testing.T) {
+	ai := &v1.AstraConnector{}
+	assert.False(t, ai.IsPaused())
+
+	ai.Annotations = map[string]string{v1.PausedAnnotation: "false"}
+	assert.False(t, ai.IsPaused())
+
+	ai.Annotations[v1.PausedAnnotation] = "true"
+	assert.True(t, ai.IsPaused())
+}
@@ -0,0 +1,19 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package v1
+
+// Severities of a pre-check, only a failed pre-check of severity error blocks the installation
+const (
+	PrecheckSeverityError   = "error"
+	PrecheckSeverityWarning = "warning"
+	PrecheckSeverityInfo    = "info"
+)
+
+// Results of a pre-check reported in AstraConnectorStatus.Prechecks
+const (
+	PrecheckResultPassed  = "Passed"
+	PrecheckResultFailed  = "Failed"
+	PrecheckResultSkipped = "Skipped"
+)
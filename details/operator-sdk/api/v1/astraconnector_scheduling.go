@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package v1
+
+import (
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ApplyTo Merges the scheduling fields into the pod spec of a component, which may already hold built-in defaults
+func (s *Scheduling) ApplyTo(podSpec *corev1.PodSpec) {
+	if s == nil {
+		return
+	}
+
+	if len(s.NodeSelector) > 0 {
+		if podSpec.NodeSelector == nil {
+			podSpec.NodeSelector = map[string]string{}
+		}
+		maps.Copy(podSpec.NodeSelector, s.NodeSelector)
+	}
+
+	podSpec.Tolerations = append(podSpec.Tolerations, s.Tolerations...)
+	podSpec.TopologySpreadConstraints = append(podSpec.TopologySpreadConstraints, s.TopologySpreadConstraints...)
+
+	if s.PriorityClassName != "" {
+		podSpec.PriorityClassName = s.PriorityClassName
+	}
+
+	if s.Affinity != nil {
+		podSpec.Affinity = mergeAffinity(podSpec.Affinity, s.Affinity.DeepCopy())
+	}
+}
+
+// mergeAffinity Combines the node affinity of both so that both must be satisfied,
+// pod affinity and anti-affinity terms are added to those of the base
+func mergeAffinity(base, overlay *corev1.Affinity) *corev1.Affinity {
+	if base == nil {
+		return overlay
+	}
+
+	merged := base.DeepCopy()
+	merged.NodeAffinity = mergeNodeAffinity(merged.NodeAffinity, overlay.NodeAffinity)
+
+	if overlay.PodAffinity != nil {
+		if merged.PodAffinity == nil {
+			merged.PodAffinity = &corev1.PodAffinity{}
+		}
+		merged.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(
+			merged.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution, overlay.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution...)
+		merged.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+			merged.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution, overlay.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution...)
+	}
+
+	if overlay.PodAntiAffinity != nil {
+		if merged.PodAntiAffinity == nil {
+			merged.PodAntiAffinity = &corev1.PodAntiAffinity{}
+		}
+		merged.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(
+			merged.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, overlay.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution...)
+		merged.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+			merged.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, overlay.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution...)
+	}
+
+	return merged
+}
+
+// mergeNodeAffinity Node selector terms are ORed, so every term of the overlay is combined with every term of the base
+// to require both. Preferred terms are weighted independently and are simply added.
+func mergeNodeAffinity(base, overlay *corev1.NodeAffinity) *corev1.NodeAffinity {
+	if overlay == nil {
+		return base
+	}
+	if base == nil {
+		return overlay
+	}
+
+	merged := base.DeepCopy()
+	merged.PreferredDuringSchedulingIgnoredDuringExecution = append(
+		merged.PreferredDuringSchedulingIgnoredDuringExecution, overlay.PreferredDuringSchedulingIgnoredDuringExecution...)
+
+	overlayRequired := overlay.RequiredDuringSchedulingIgnoredDuringExecution
+	if overlayRequired == nil || len(overlayRequired.NodeSelectorTerms) == 0 {
+		return merged
+	}
+	if merged.RequiredDuringSchedulingIgnoredDuringExecution == nil || len(merged.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms) == 0 {
+		merged.RequiredDuringSchedulingIgnoredDuringExecution = overlayRequired
+		return merged
+	}
+
+	var terms []corev1.NodeSelectorTerm
+	for _, baseTerm := range merged.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		for _, overlayTerm := range overlayRequired.NodeSelectorTerms {
+			terms = append(terms, corev1.NodeSelectorTerm{
+				MatchExpressions: append(append([]corev1.NodeSelectorRequirement{}, baseTerm.MatchExpressions...), overlayTerm.MatchExpressions...),
+				MatchFields:      append(append([]corev1.NodeSelectorRequirement{}, baseTerm.MatchFields...), overlayTerm.MatchFields...),
+			})
+		}
+	}
+	merged.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms = terms
+	return merged
+}
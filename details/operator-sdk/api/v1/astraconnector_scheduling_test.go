@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package v1_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+)
+
+func requiredNodeAffinity(terms ...corev1.NodeSelectorTerm) *corev1.Affinity {
+	return &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: terms},
+	}}
+}
+
+func nodeSelectorTerm(key string, values ...string) corev1.NodeSelectorTerm {
+	return corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+		{Key: key, Operator: corev1.NodeSelectorOpIn, Values: values},
+	}}
+}
+
+func TestScheduling_ApplyTo(t *testing.T) {
+	t.Run("ApplyTo__EmptySchedulingKeepsDefaults", func(t *testing.T) {
+		podSpec := corev1.PodSpec{Affinity: requiredNodeAffinity(nodeSelectorTerm("kubernetes.io/os", "linux"))}
+		(&v1.Scheduling{}).ApplyTo(&podSpec)
+
+		assert.Equal(t, requiredNodeAffinity(nodeSelectorTerm("kubernetes.io/os", "linux")), podSpec.Affinity)
+		assert.Empty(t, podSpec.NodeSelector)
+		assert.Empty(t, podSpec.Tolerations)
+	})
+
+	t.Run("ApplyTo__FieldsAreMerged", func(t *testing.T) {
+		podSpec := corev1.PodSpec{
+			NodeSelector: map[string]string{"kubernetes.io/os": "linux", "tier": "default"},
+			Tolerations:  []corev1.Toleration{{Key: "existing", Operator: corev1.TolerationOpExists}},
+		}
+		scheduling := &v1.Scheduling{
+			NodeSelector:      map[string]string{"tier": "infra"},
+			Tolerations:       []corev1.Toleration{{Key: "node-role.kubernetes.io/infra", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}},
+			PriorityClassName: "system-cluster-critical",
+			TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+				{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.ScheduleAnyway},
+			},
+		}
+		scheduling.ApplyTo(&podSpec)
+
+		assert.Equal(t, map[string]string{"kubernetes.io/os": "linux", "tier": "infra"}, podSpec.NodeSelector)
+		assert.Equal(t, 2, len(podSpec.Tolerations))
+		assert.Equal(t, "node-role.kubernetes.io/infra", podSpec.Tolerations[1].Key)
+		assert.Equal(t, "system-cluster-critical", podSpec.PriorityClassName)
+		assert.Equal(t, 1, len(podSpec.TopologySpreadConstraints))
+		// The scheduling spec itself is not modified
+		assert.Equal(t, map[string]string{"tier": "infra"}, scheduling.NodeSelector)
+	})
+
+	t.Run("ApplyTo__NodeAffinityTermsMustAllBeSatisfied", func(t *testing.T) {
+		podSpec := corev1.PodSpec{Affinity: requiredNodeAffinity(nodeSelectorTerm("kubernetes.io/os", "linux"))}
+		scheduling := &v1.Scheduling{
+			Affinity: requiredNodeAffinity(
+				nodeSelectorTerm("node-role.kubernetes.io/infra", "true"),
+				nodeSelectorTerm("dedicated", "astra"),
+			),
+		}
+		scheduling.ApplyTo(&podSpec)
+
+		terms := podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		assert.Equal(t, 2, len(terms))
+		for _, term := range terms {
+			assert.Equal(t, 2, len(term.MatchExpressions))
+			assert.Equal(t, "kubernetes.io/os", term.MatchExpressions[0].Key)
+		}
+		assert.Equal(t, "node-role.kubernetes.io/infra", terms[0].MatchExpressions[1].Key)
+		assert.Equal(t, "dedicated", terms[1].MatchExpressions[1].Key)
+	})
+
+	t.Run("ApplyTo__PodAntiAffinityIsAdded", func(t *testing.T) {
+		podSpec := corev1.PodSpec{Affinity: requiredNodeAffinity(nodeSelectorTerm("kubernetes.io/os", "linux"))}
+		scheduling := &v1.Scheduling{
+			Affinity: &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+					{Weight: 100, PodAffinityTerm: corev1.PodAffinityTerm{TopologyKey: "kubernetes.io/hostname"}},
+				},
+			}},
+		}
+		scheduling.ApplyTo(&podSpec)
+
+		assert.NotNil(t, podSpec.Affinity.NodeAffinity)
+		assert.Equal(t, 1, len(podSpec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution))
+	})
+}
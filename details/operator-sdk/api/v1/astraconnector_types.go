@@ -23,6 +23,18 @@ type Astra struct {
 	TokenRef          string `json:"tokenRef,omitempty"`
 	// +kubebuilder:validation:Optional
 	Unregister bool `json:"unregister,omitempty"`
+	// CABundleRef references a PEM encoded CA bundle that is trusted when connecting to Astra Control,
+	// for Astra Control Center installations that use an internal CA
+	// +kubebuilder:validation:Optional
+	CABundleRef *CABundleRef `json:"caBundleRef,omitempty"`
+}
+
+// CABundleRef selects a key of a ConfigMap or a Secret in the namespace of the AstraConnector, exactly one must be set
+type CABundleRef struct {
+	// +kubebuilder:validation:Optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+	// +kubebuilder:validation:Optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
 }
 
 // AutoSupport defines how the customer interacts with NetApp ActiveIQ.
@@ -61,6 +73,7 @@ type AstraConnect struct {
 	// +kubebuilder:default:=1
 	Replicas             int32                       `json:"replicas,omitempty"`
 	ResourceRequirements corev1.ResourceRequirements `json:"resources,omitempty"`
+	Scheduling           `json:",inline"`
 }
 
 // Neptune
@@ -69,6 +82,23 @@ type Neptune struct {
 	Image                string                      `json:"image,omitempty"`
 	JobImagePullPolicy   string                      `json:"jobImagePullPolicy,omitempty"`
 	ResourceRequirements corev1.ResourceRequirements `json:"resources,omitempty"`
+	Scheduling           `json:",inline"`
+}
+
+// Scheduling defines where the pods of a component are scheduled, the fields are merged into the pod spec
+// +kubebuilder:validation:Optional
+type Scheduling struct {
+	// NodeSelector is merged with the node selector of the component, the values given here take precedence
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations are added to the tolerations of the component
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// Affinity is merged with the affinity of the component, node selector terms are combined with the
+	// built-in node affinity so both must be satisfied
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// TopologySpreadConstraints are added to the topology spread constraints of the component
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// PriorityClassName is the priority class of the pods of the component
+	PriorityClassName string `json:"priorityClassName,omitempty"`
 }
 
 // AstraConnectorSpec defines the desired state of AstraConnector
@@ -92,13 +122,130 @@ type AstraConnectorSpec struct {
 	// +kubebuilder:default:=false
 	SkipPreCheck bool `json:"skipPreCheck"`
 
+	// Precheck configures the pre-checks run before the installation
+	// +kubebuilder:validation:Optional
+	Precheck Precheck `json:"precheck,omitempty"`
+
 	// Labels any additional labels wanted to be added to resources
 	Labels map[string]string `json:"labels"`
+
+	// Proxy routes the traffic of the operator and the connector components to Astra through an egress proxy
+	// +kubebuilder:validation:Optional
+	Proxy *Proxy `json:"proxy,omitempty"`
+}
+
+// Precheck configures the pre-checks run before the installation
+type Precheck struct {
+	// Skip lists the names of the pre-checks that are not run, e.g. k8s-version
+	// +kubebuilder:validation:Optional
+	Skip []string `json:"skip,omitempty"`
+}
+
+// Proxy defines the HTTP(S) proxy used for traffic to Astra
+type Proxy struct {
+	// HTTPProxy is the proxy URL used for http requests, e.g. http://proxy.example.com:3128
+	// +kubebuilder:validation:Optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+	// HTTPSProxy is the proxy URL used for https requests, e.g. http://proxy.example.com:3128
+	// +kubebuilder:validation:Optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	// NoProxy is a comma separated list of hosts, domains and CIDRs that are not proxied
+	// +kubebuilder:validation:Optional
+	NoProxy string `json:"noProxy,omitempty"`
+	// CredentialsSecretRef is the name of a Secret with username and password keys used to authenticate with the proxy
+	// +kubebuilder:validation:Optional
+	CredentialsSecretRef string `json:"credentialsSecretRef,omitempty"`
 }
 
 // AstraConnectorStatus defines the observed state of AstraConnector
 type AstraConnectorStatus struct {
 	NatsSyncClient NatsSyncClientStatus `json:"natsSyncClient"`
+
+	// ObservedGeneration is the most recent generation of the AstraConnector spec that was fully reconciled.
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the AstraConnector's state.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metaV1.Condition `json:"conditions,omitempty"`
+
+	// Prechecks are the results of the pre-checks of the last reconcile
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	Prechecks []PrecheckResult `json:"prechecks,omitempty"`
+
+	// Trident is the Trident installation found on the cluster by the trident pre-check
+	// +kubebuilder:validation:Optional
+	Trident *TridentStatus `json:"trident,omitempty"`
+
+	// Cluster is the kubernetes cluster the connector runs on, detected again for a new generation of the
+	// AstraConnector or a new kubernetes version. It is not set while the flavor cannot be determined.
+	// +kubebuilder:validation:Optional
+	Cluster *ClusterStatus `json:"cluster,omitempty"`
+
+	// Images are the images the deployed components run, the images the spec leaves empty are resolved to the
+	// tags of the running operator
+	// +kubebuilder:validation:Optional
+	Images *ImagesStatus `json:"images,omitempty"`
+}
+
+// ImagesStatus lists the effective images of the components deployed for the AstraConnector
+type ImagesStatus struct {
+	// Connector is the Astra Connect image
+	// +kubebuilder:validation:Optional
+	Connector string `json:"connector,omitempty"`
+	// Neptune is the Neptune controller image
+	// +kubebuilder:validation:Optional
+	Neptune string `json:"neptune,omitempty"`
+}
+
+// ClusterStatus describes the kubernetes cluster the connector runs on
+type ClusterStatus struct {
+	// Flavor of the cluster, e.g. openshift, eks or kubernetes
+	Flavor string `json:"flavor"`
+	// KubernetesVersion is the git version of the API server
+	// +kubebuilder:validation:Optional
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// OpenShiftVersion is the version of OpenShift, only set on OpenShift flavors
+	// +kubebuilder:validation:Optional
+	OpenShiftVersion string `json:"openShiftVersion,omitempty"`
+	// ObservedGeneration is the generation of the AstraConnector the cluster was detected for
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// PrecheckResult is the outcome of a pre-check
+type PrecheckResult struct {
+	// Name of the pre-check
+	Name string `json:"name"`
+	// Severity of the outcome, only a failed pre-check of severity error blocks the installation
+	// +kubebuilder:validation:Enum=error;warning;info
+	Severity string `json:"severity"`
+	// Result of the pre-check
+	// +kubebuilder:validation:Enum=Passed;Failed;Skipped
+	Result string `json:"result"`
+	// Message describes why the pre-check failed
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+// TridentStatus describes the Trident installed by the Trident operator or tridentctl
+type TridentStatus struct {
+	// Installed is true if a TridentOrchestrator or, for tridentctl installs, a TridentVersion was found
+	Installed bool `json:"installed"`
+	// Namespace Trident is installed in
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+	// Version of Trident
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+	// ACPEnabled is true if the Astra Control Provisioner is enabled in the TridentOrchestrator, it is not
+	// detected for tridentctl installs
+	// +kubebuilder:validation:Optional
+	ACPEnabled bool `json:"acpEnabled,omitempty"`
 }
 
 // NatsSyncClientStatus defines the observed state of NatsSyncClient
@@ -120,6 +267,8 @@ type ImageRegistry struct {
 //+kubebuilder:printcolumn:name="Registered",type=string,JSONPath=`.status.natsSyncClient.registered`
 //+kubebuilder:printcolumn:name="AstraClusterID",type=string,JSONPath=`.status.natsSyncClient.astraClusterID`
 //+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.natsSyncClient.status`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Flavor",type=string,JSONPath=`.status.cluster.flavor`,priority=1
 
 // AstraConnector is the Schema for the astraconnectors API
 // +kubebuilder:subresource:status
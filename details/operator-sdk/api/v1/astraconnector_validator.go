@@ -4,7 +4,12 @@ package v1
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"net/url"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -13,6 +18,10 @@ import (
 
 var log = ctrllog.FromContext(context.TODO())
 
+// ReregisterAnnotation allows accountId, cloudId and clusterId to be changed on a registered AstraConnector.
+// The controller removes it again once the cluster is registered with the new clusterId.
+const ReregisterAnnotation = "astra.netapp.io/reregister"
+
 func (ai *AstraConnector) ValidateCreateAstraConnector() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -24,10 +33,58 @@ func (ai *AstraConnector) ValidateCreateAstraConnector() field.ErrorList {
 	return allErrs
 }
 
-func (ai *AstraConnector) ValidateUpdateAstraConnector() field.ErrorList {
-	// TODO - Add validations here
+func (ai *AstraConnector) ValidateUpdateAstraConnector(old *AstraConnector) field.ErrorList {
 	astraConnectorLog.Info("Updating AstraConnector resource")
-	return nil
+	var allErrs field.ErrorList
+
+	if old == nil || !old.IsRegistered() || ai.IsReregisterRequested() {
+		return allErrs
+	}
+
+	astraPath := field.NewPath(util.GetJSONFieldName(ai, &ai.Spec)).Child(util.GetJSONFieldName(&ai.Spec, &ai.Spec.Astra))
+	identityFields := []struct {
+		name     string
+		oldValue string
+		newValue string
+	}{
+		{util.GetJSONFieldName(&ai.Spec.Astra, &ai.Spec.Astra.AccountId), old.Spec.Astra.AccountId, ai.Spec.Astra.AccountId},
+		{util.GetJSONFieldName(&ai.Spec.Astra, &ai.Spec.Astra.CloudId), old.Spec.Astra.CloudId, ai.Spec.Astra.CloudId},
+		{util.GetJSONFieldName(&ai.Spec.Astra, &ai.Spec.Astra.ClusterId), old.Spec.Astra.ClusterId, ai.Spec.Astra.ClusterId},
+	}
+
+	for _, identityField := range identityFields {
+		if identityField.oldValue != identityField.newValue {
+			allErrs = append(allErrs, field.Invalid(astraPath.Child(identityField.name), identityField.newValue,
+				fmt.Sprintf("field is immutable once the cluster is registered with Astra, set the annotation %s: \"true\" to re-register", ReregisterAnnotation)))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateRegisteredIdentity Validates that the clusterId in the spec still matches the cluster that was registered.
+// This protects the identity of a registered connector when the admission webhook is not deployed.
+func (ai *AstraConnector) ValidateRegisteredIdentity() *field.Error {
+	registeredClusterId := ai.Status.NatsSyncClient.AstraClusterId
+	if !ai.IsRegistered() || ai.IsReregisterRequested() || registeredClusterId == "" || registeredClusterId == ai.Spec.Astra.ClusterId {
+		return nil
+	}
+
+	clusterIdPath := field.NewPath(util.GetJSONFieldName(ai, &ai.Spec)).
+		Child(util.GetJSONFieldName(&ai.Spec, &ai.Spec.Astra)).
+		Child(util.GetJSONFieldName(&ai.Spec.Astra, &ai.Spec.Astra.ClusterId))
+	return field.Invalid(clusterIdPath, ai.Spec.Astra.ClusterId,
+		fmt.Sprintf("does not match registered cluster %s, set the annotation %s: \"true\" to re-register", registeredClusterId, ReregisterAnnotation))
+}
+
+// IsRegistered Returns true if the status reports the cluster as registered with Astra
+func (ai *AstraConnector) IsRegistered() bool {
+	return ai.Status.NatsSyncClient.Registered == "true"
+}
+
+// IsReregisterRequested Returns true if the user explicitly allowed the cluster identity to change
+func (ai *AstraConnector) IsReregisterRequested() bool {
+	return ai.GetAnnotations()[ReregisterAnnotation] == "true"
 }
 
 // ValidateNamespace Validates the namespace that AstraConnector should be deployed to.
@@ -39,3 +96,177 @@ func (ai *AstraConnector) ValidateNamespace() *field.Error {
 	}
 	return nil
 }
+
+// ValidateSpec Validates the fields of the AstraConnector spec that would otherwise only fail during reconcile.
+// The admission webhook rejects these errors, the controller reports them on the Ready condition of a CR that is not
+// being deleted, so a CR is still deleted and a default install without the webhook reports them too.
+func (ai *AstraConnector) ValidateSpec() field.ErrorList {
+	var allErrs field.ErrorList
+
+	specPath := field.NewPath(util.GetJSONFieldName(ai, &ai.Spec))
+	astraPath := specPath.Child(util.GetJSONFieldName(&ai.Spec, &ai.Spec.Astra))
+	natsSyncClientPath := specPath.Child(util.GetJSONFieldName(&ai.Spec, &ai.Spec.NatsSyncClient))
+
+	if ai.Spec.Astra.TokenRef == "" {
+		allErrs = append(allErrs, field.Required(astraPath.Child(util.GetJSONFieldName(&ai.Spec.Astra, &ai.Spec.Astra.TokenRef)),
+			"secret reference to the Astra API token must be provided"))
+	}
+
+	if ai.Spec.Astra.ClusterId == "" && ai.Spec.Astra.ClusterName == "" {
+		allErrs = append(allErrs, field.Required(astraPath.Child(util.GetJSONFieldName(&ai.Spec.Astra, &ai.Spec.Astra.ClusterId)),
+			"clusterId and clusterName both cannot be empty"))
+	}
+
+	if ai.Spec.Astra.CABundleRef != nil {
+		allErrs = append(allErrs, validateCABundleRef(ai.Spec.Astra.CABundleRef,
+			astraPath.Child(util.GetJSONFieldName(&ai.Spec.Astra, &ai.Spec.Astra.CABundleRef)))...)
+	}
+
+	if cloudBridgeURL := ai.Spec.NatsSyncClient.CloudBridgeURL; cloudBridgeURL != "" {
+		cloudBridgeURLPath := natsSyncClientPath.Child(util.GetJSONFieldName(&ai.Spec.NatsSyncClient, &ai.Spec.NatsSyncClient.CloudBridgeURL))
+		if err := validateURL(cloudBridgeURL); err != nil {
+			allErrs = append(allErrs, field.Invalid(cloudBridgeURLPath, cloudBridgeURL, err.Error()))
+		}
+	}
+
+	if hostAliasIP := ai.Spec.NatsSyncClient.HostAliasIP; hostAliasIP != "" && net.ParseIP(hostAliasIP) == nil {
+		hostAliasIPPath := natsSyncClientPath.Child(util.GetJSONFieldName(&ai.Spec.NatsSyncClient, &ai.Spec.NatsSyncClient.HostAliasIP))
+		allErrs = append(allErrs, field.Invalid(hostAliasIPPath, hostAliasIP, "must be a valid IPv4 or IPv6 address"))
+	}
+
+	if ai.Spec.Proxy != nil {
+		allErrs = append(allErrs, validateProxy(ai.Spec.Proxy, specPath.Child(util.GetJSONFieldName(&ai.Spec, &ai.Spec.Proxy)))...)
+	}
+
+	allErrs = append(allErrs, metav1validation.ValidateLabels(ai.Spec.Labels,
+		specPath.Child(util.GetJSONFieldName(&ai.Spec, &ai.Spec.Labels)))...)
+
+	astraConnectPath := specPath.Child(util.GetJSONFieldName(&ai.Spec, &ai.Spec.AstraConnect))
+	allErrs = append(allErrs, validateResourceRequirements(ai.Spec.AstraConnect.ResourceRequirements,
+		astraConnectPath.Child(util.GetJSONFieldName(&ai.Spec.AstraConnect, &ai.Spec.AstraConnect.ResourceRequirements)))...)
+
+	neptunePath := specPath.Child(util.GetJSONFieldName(&ai.Spec, &ai.Spec.Neptune))
+	allErrs = append(allErrs, validateResourceRequirements(ai.Spec.Neptune.ResourceRequirements,
+		neptunePath.Child(util.GetJSONFieldName(&ai.Spec.Neptune, &ai.Spec.Neptune.ResourceRequirements)))...)
+
+	return allErrs
+}
+
+// GetValidationWarnings Returns warnings for settings that are allowed but not recommended.
+func (ai *AstraConnector) GetValidationWarnings() []string {
+	var warnings []string
+
+	if ai.Spec.Astra.SkipTLSValidation {
+		warnings = append(warnings, "spec.astra.skipTLSValidation is enabled, TLS certificates of Astra Control will not be verified. Not for use in production")
+	}
+
+	if ai.Spec.Astra.SkipTLSValidation && ai.Spec.Astra.CABundleRef != nil {
+		warnings = append(warnings, "spec.astra.caBundleRef has no effect while spec.astra.skipTLSValidation is enabled")
+	}
+
+	if ai.Spec.AstraConnect.Replicas > 1 {
+		warnings = append(warnings, fmt.Sprintf("spec.astraConnect.replicas is set to %d, only a single replica of astraconnect is supported", ai.Spec.AstraConnect.Replicas))
+	}
+
+	return warnings
+}
+
+// validateURL Checks the URL is absolute and uses the http or https scheme, e.g. https://astra.netapp.io
+func validateURL(rawURL string) error {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("must be a valid URL: %v", err)
+	}
+	if parsedURL.Scheme != "https" && parsedURL.Scheme != "http" {
+		return fmt.Errorf("must use the http or https scheme, format - https://hostname")
+	}
+	if parsedURL.Host == "" {
+		return fmt.Errorf("must include a hostname, format - https://hostname")
+	}
+	return nil
+}
+
+// validateCABundleRef Checks exactly one of the ConfigMap or Secret key references is set and that it is complete
+func validateCABundleRef(caBundleRef *CABundleRef, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	configMapPath := fldPath.Child(util.GetJSONFieldName(caBundleRef, &caBundleRef.ConfigMapKeyRef))
+	secretPath := fldPath.Child(util.GetJSONFieldName(caBundleRef, &caBundleRef.SecretKeyRef))
+
+	switch {
+	case caBundleRef.ConfigMapKeyRef == nil && caBundleRef.SecretKeyRef == nil:
+		allErrs = append(allErrs, field.Required(fldPath, "one of configMapKeyRef or secretKeyRef must be set"))
+	case caBundleRef.ConfigMapKeyRef != nil && caBundleRef.SecretKeyRef != nil:
+		allErrs = append(allErrs, field.Invalid(fldPath, "", "only one of configMapKeyRef or secretKeyRef may be set"))
+	case caBundleRef.ConfigMapKeyRef != nil:
+		allErrs = append(allErrs, validateKeyRef(caBundleRef.ConfigMapKeyRef.Name, caBundleRef.ConfigMapKeyRef.Key, configMapPath)...)
+	default:
+		allErrs = append(allErrs, validateKeyRef(caBundleRef.SecretKeyRef.Name, caBundleRef.SecretKeyRef.Key, secretPath)...)
+	}
+
+	return allErrs
+}
+
+// validateProxy Checks the proxy URLs are valid and that credentials are only given together with a proxy
+func validateProxy(proxy *Proxy, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	proxyURLs := []struct {
+		path  *field.Path
+		value string
+	}{
+		{fldPath.Child(util.GetJSONFieldName(proxy, &proxy.HTTPProxy)), proxy.HTTPProxy},
+		{fldPath.Child(util.GetJSONFieldName(proxy, &proxy.HTTPSProxy)), proxy.HTTPSProxy},
+	}
+	for _, proxyURL := range proxyURLs {
+		if proxyURL.value == "" {
+			continue
+		}
+		if err := validateURL(proxyURL.value); err != nil {
+			allErrs = append(allErrs, field.Invalid(proxyURL.path, proxyURL.value, err.Error()))
+		}
+	}
+
+	if proxy.CredentialsSecretRef != "" && proxy.HTTPProxy == "" && proxy.HTTPSProxy == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child(util.GetJSONFieldName(proxy, &proxy.CredentialsSecretRef)),
+			proxy.CredentialsSecretRef, "httpProxy or httpsProxy must be set to use proxy credentials"))
+	}
+
+	return allErrs
+}
+
+// validateKeyRef Checks both the name and the key of a ConfigMap or Secret key reference are set
+func validateKeyRef(name, key string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), "name must be set"))
+	}
+	if key == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("key"), "key must be set"))
+	}
+	return allErrs
+}
+
+// validateResourceRequirements Checks quantities are not negative and requests do not exceed limits
+func validateResourceRequirements(requirements corev1.ResourceRequirements, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for name, quantity := range requirements.Limits {
+		if quantity.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("limits").Key(string(name)), quantity.String(), "must be greater than or equal to 0"))
+		}
+	}
+
+	for name, quantity := range requirements.Requests {
+		if quantity.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("requests").Key(string(name)), quantity.String(), "must be greater than or equal to 0"))
+			continue
+		}
+		if limit, ok := requirements.Limits[name]; ok && quantity.Cmp(limit) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("requests").Key(string(name)), quantity.String(),
+				fmt.Sprintf("must be less than or equal to %s limit of %s", name, limit.String())))
+		}
+	}
+
+	return allErrs
+}
@@ -8,12 +8,37 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
 )
 
+func createValidAstraConnector() *v1.AstraConnector {
+	return &v1.AstraConnector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "astra-connector",
+			Namespace: "astra-connector",
+		},
+		Spec: v1.AstraConnectorSpec{
+			Astra: v1.Astra{
+				AccountId:   "test-account-id",
+				ClusterName: "test-cluster-name",
+				TokenRef:    "astra-token",
+			},
+			NatsSyncClient: v1.NatsSyncClient{
+				CloudBridgeURL: "https://astra.netapp.io",
+				HostAliasIP:    "10.193.60.80",
+			},
+			Labels: map[string]string{"app.kubernetes.io/part-of": "astra"},
+		},
+	}
+}
+
 func TestAstraConnector_ValidateCreateAstraConnector(t *testing.T) {
-	ai := &v1.AstraConnector{}
+	ai := createValidAstraConnector()
 	err := ai.ValidateCreateAstraConnector()
 
 	// Validate that no error occurred
@@ -22,9 +47,17 @@ func TestAstraConnector_ValidateCreateAstraConnector(t *testing.T) {
 	}
 }
 
+func TestAstraConnector_ValidateCreateAstraConnectorIgnoresSpec(t *testing.T) {
+	// Spec rules are left to the webhook so the controller never blocks existing CRs
+	ai := createValidAstraConnector()
+	ai.Spec.Astra.TokenRef = ""
+
+	assert.Empty(t, ai.ValidateCreateAstraConnector())
+}
+
 func TestAstraConnector_ValidateUpdateAstraConnector(t *testing.T) {
 	ai := &v1.AstraConnector{}
-	err := ai.ValidateUpdateAstraConnector()
+	err := ai.ValidateUpdateAstraConnector(&v1.AstraConnector{})
 
 	// Validate that no error occurred
 	if err != nil {
@@ -32,8 +65,103 @@ func TestAstraConnector_ValidateUpdateAstraConnector(t *testing.T) {
 	}
 }
 
+func createRegisteredAstraConnector() *v1.AstraConnector {
+	ai := createValidAstraConnector()
+	ai.Spec.Astra.CloudId = "test-cloud-id"
+	ai.Spec.Astra.ClusterId = "test-cluster-id"
+	ai.Status.NatsSyncClient.Registered = "true"
+	ai.Status.NatsSyncClient.AstraClusterId = "test-cluster-id"
+	return ai
+}
+
+func TestAstraConnector_ValidateUpdateAstraConnectorIdentity(t *testing.T) {
+	testCases := []struct {
+		name          string
+		modify        func(ai *v1.AstraConnector)
+		expectedField string
+	}{
+		{
+			name:          "accountId changed",
+			modify:        func(ai *v1.AstraConnector) { ai.Spec.Astra.AccountId = "other-account-id" },
+			expectedField: "spec.astra.accountId",
+		},
+		{
+			name:          "cloudId changed",
+			modify:        func(ai *v1.AstraConnector) { ai.Spec.Astra.CloudId = "other-cloud-id" },
+			expectedField: "spec.astra.cloudId",
+		},
+		{
+			name:          "clusterId changed",
+			modify:        func(ai *v1.AstraConnector) { ai.Spec.Astra.ClusterId = "other-cluster-id" },
+			expectedField: "spec.astra.clusterId",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			oldAi := createRegisteredAstraConnector()
+			ai := createRegisteredAstraConnector()
+			tc.modify(ai)
+
+			errs := ai.ValidateUpdateAstraConnector(oldAi)
+			assert.Equal(t, 1, len(errs))
+			assert.Equal(t, tc.expectedField, errs[0].Field)
+		})
+	}
+
+	t.Run("ValidateUpdateAstraConnector__UnregisteredAllowsIdentityChange", func(t *testing.T) {
+		oldAi := createRegisteredAstraConnector()
+		oldAi.Status.NatsSyncClient.Registered = "false"
+		ai := createRegisteredAstraConnector()
+		ai.Spec.Astra.ClusterId = "other-cluster-id"
+
+		assert.Empty(t, ai.ValidateUpdateAstraConnector(oldAi))
+	})
+
+	t.Run("ValidateUpdateAstraConnector__ReregisterAnnotationAllowsIdentityChange", func(t *testing.T) {
+		oldAi := createRegisteredAstraConnector()
+		ai := createRegisteredAstraConnector()
+		ai.Spec.Astra.ClusterId = "other-cluster-id"
+		ai.Annotations = map[string]string{v1.ReregisterAnnotation: "true"}
+
+		assert.Empty(t, ai.ValidateUpdateAstraConnector(oldAi))
+	})
+
+	t.Run("ValidateUpdateAstraConnector__NonIdentityChangeAllowed", func(t *testing.T) {
+		oldAi := createRegisteredAstraConnector()
+		ai := createRegisteredAstraConnector()
+		ai.Spec.Astra.SkipTLSValidation = true
+
+		assert.Empty(t, ai.ValidateUpdateAstraConnector(oldAi))
+	})
+}
+
+func TestAstraConnector_ValidateRegisteredIdentity(t *testing.T) {
+	t.Run("ValidateRegisteredIdentity__MatchingClusterIdReturnsNil", func(t *testing.T) {
+		ai := createRegisteredAstraConnector()
+		assert.Nil(t, ai.ValidateRegisteredIdentity())
+	})
+
+	t.Run("ValidateRegisteredIdentity__ChangedClusterIdReturnsError", func(t *testing.T) {
+		ai := createRegisteredAstraConnector()
+		ai.Spec.Astra.ClusterId = "other-cluster-id"
+
+		err := ai.ValidateRegisteredIdentity()
+		assert.NotNil(t, err)
+		assert.Equal(t, "spec.astra.clusterId", err.Field)
+	})
+
+	t.Run("ValidateRegisteredIdentity__ReregisterAnnotationReturnsNil", func(t *testing.T) {
+		ai := createRegisteredAstraConnector()
+		ai.Spec.Astra.ClusterId = "other-cluster-id"
+		ai.Annotations = map[string]string{v1.ReregisterAnnotation: "true"}
+
+		assert.Nil(t, ai.ValidateRegisteredIdentity())
+	})
+}
+
 func TestAstraConnector_ValidateNamespace(t *testing.T) {
-	ai := &v1.AstraConnector{}
+	ai := createValidAstraConnector()
 	ai.ObjectMeta.Namespace = "default"
 
 	errors := ai.ValidateCreateAstraConnector()
@@ -49,3 +177,163 @@ func TestAstraConnector_ValidateNamespace(t *testing.T) {
 	assert.Equal(t, expectedErrMsg, errors[0].Detail)
 	assert.Equal(t, "namespace", errors[0].Field)
 }
+
+func TestAstraConnector_ValidateSpec(t *testing.T) {
+	testCases := []struct {
+		name          string
+		modify        func(ai *v1.AstraConnector)
+		expectedField string
+	}{
+		{
+			name:          "missing tokenRef",
+			modify:        func(ai *v1.AstraConnector) { ai.Spec.Astra.TokenRef = "" },
+			expectedField: "spec.astra.tokenRef",
+		},
+		{
+			name: "clusterId and clusterName empty",
+			modify: func(ai *v1.AstraConnector) {
+				ai.Spec.Astra.ClusterId = ""
+				ai.Spec.Astra.ClusterName = ""
+			},
+			expectedField: "spec.astra.clusterId",
+		},
+		{
+			name:          "cloudBridgeURL without scheme",
+			modify:        func(ai *v1.AstraConnector) { ai.Spec.NatsSyncClient.CloudBridgeURL = "astra.netapp.io" },
+			expectedField: "spec.natsSyncClient.cloudBridgeURL",
+		},
+		{
+			name:          "cloudBridgeURL without host",
+			modify:        func(ai *v1.AstraConnector) { ai.Spec.NatsSyncClient.CloudBridgeURL = "https://" },
+			expectedField: "spec.natsSyncClient.cloudBridgeURL",
+		},
+		{
+			name:          "invalid hostAliasIP",
+			modify:        func(ai *v1.AstraConnector) { ai.Spec.NatsSyncClient.HostAliasIP = "10.0.0" },
+			expectedField: "spec.natsSyncClient.hostAliasIP",
+		},
+		{
+			name:          "invalid label key",
+			modify:        func(ai *v1.AstraConnector) { ai.Spec.Labels = map[string]string{"bad key!": "value"} },
+			expectedField: "spec.labels",
+		},
+		{
+			name:          "caBundleRef without a reference",
+			modify:        func(ai *v1.AstraConnector) { ai.Spec.Astra.CABundleRef = &v1.CABundleRef{} },
+			expectedField: "spec.astra.caBundleRef",
+		},
+		{
+			name: "caBundleRef with both references",
+			modify: func(ai *v1.AstraConnector) {
+				ai.Spec.Astra.CABundleRef = &v1.CABundleRef{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "ca"}, Key: "ca.crt"},
+					SecretKeyRef:    &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "ca"}, Key: "ca.crt"},
+				}
+			},
+			expectedField: "spec.astra.caBundleRef",
+		},
+		{
+			name: "caBundleRef without a key",
+			modify: func(ai *v1.AstraConnector) {
+				ai.Spec.Astra.CABundleRef = &v1.CABundleRef{
+					SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "ca"}},
+				}
+			},
+			expectedField: "spec.astra.caBundleRef.secretKeyRef.key",
+		},
+		{
+			name:          "invalid httpsProxy",
+			modify:        func(ai *v1.AstraConnector) { ai.Spec.Proxy = &v1.Proxy{HTTPSProxy: "proxy.example.com:3128"} },
+			expectedField: "spec.proxy.httpsProxy",
+		},
+		{
+			name:          "proxy credentials without a proxy",
+			modify:        func(ai *v1.AstraConnector) { ai.Spec.Proxy = &v1.Proxy{CredentialsSecretRef: "proxy-credentials"} },
+			expectedField: "spec.proxy.credentialsSecretRef",
+		},
+		{
+			name: "negative resource quantity",
+			modify: func(ai *v1.AstraConnector) {
+				ai.Spec.AstraConnect.ResourceRequirements.Limits = corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("-1Gi"),
+				}
+			},
+			expectedField: "spec.astraConnect.resources.limits[memory]",
+		},
+		{
+			name: "request greater than limit",
+			modify: func(ai *v1.AstraConnector) {
+				ai.Spec.Neptune.ResourceRequirements = corev1.ResourceRequirements{
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+				}
+			},
+			expectedField: "spec.neptune.resources.requests[memory]",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ai := createValidAstraConnector()
+			tc.modify(ai)
+
+			errs := ai.ValidateSpec()
+			assert.Equal(t, 1, len(errs))
+			assert.Contains(t, errs[0].Field, tc.expectedField)
+		})
+	}
+}
+
+func TestAstraConnector_ValidateCreate(t *testing.T) {
+	t.Run("ValidateCreate__ValidAstraConnectorIsAdmitted", func(t *testing.T) {
+		ai := createValidAstraConnector()
+
+		warnings, err := ai.ValidateCreate()
+		assert.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("ValidateCreate__InvalidAstraConnectorIsRejected", func(t *testing.T) {
+		ai := createValidAstraConnector()
+		ai.Namespace = "default"
+		ai.Spec.Astra.TokenRef = ""
+
+		_, err := ai.ValidateCreate()
+		assert.Error(t, err)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "default namespace not allowed")
+		assert.Contains(t, err.Error(), "spec.astra.tokenRef")
+	})
+
+	t.Run("ValidateCreate__SkipTLSValidationReturnsWarning", func(t *testing.T) {
+		ai := createValidAstraConnector()
+		ai.Spec.Astra.SkipTLSValidation = true
+
+		warnings, err := ai.ValidateCreate()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(warnings))
+		assert.Contains(t, warnings[0], "skipTLSValidation")
+	})
+}
+
+func TestAstraConnector_ValidateUpdate(t *testing.T) {
+	t.Run("ValidateUpdate__InvalidSpecIsRejected", func(t *testing.T) {
+		oldAi := createValidAstraConnector()
+		ai := createValidAstraConnector()
+		ai.Spec.NatsSyncClient.HostAliasIP = "not-an-ip"
+
+		_, err := ai.ValidateUpdate(oldAi)
+		assert.Error(t, err)
+		assert.True(t, apierrors.IsInvalid(err))
+	})
+
+	t.Run("ValidateUpdate__ValidSpecIsAdmitted", func(t *testing.T) {
+		oldAi := createValidAstraConnector()
+		ai := createValidAstraConnector()
+		ai.Spec.AstraConnect.Replicas = 2
+
+		warnings, err := ai.ValidateUpdate(oldAi)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(warnings))
+	})
+}
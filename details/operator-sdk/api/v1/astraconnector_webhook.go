@@ -3,11 +3,17 @@
 package v1
 
 import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/NetApp-Polaris/astra-connector-operator/common"
 )
 
 // log is for logging in this package.
@@ -26,10 +32,32 @@ func (ai *AstraConnector) SetupWebhookWithManager(mgr ctrl.Manager) error {
 var _ webhook.Defaulter = &AstraConnector{}
 
 // Default implements webhook.Defaulter so a webhook will be registered for the type
+// The defaults match what the deployers fall back to, so the stored CR shows the effective configuration.
+// Images are left empty, the deployers resolve them to the tags of the running operator so an upgrade rolls them,
+// the effective images are reported in status.images.
 func (ai *AstraConnector) Default() {
 	astraConnectorLog.Info("default", "name", ai.Name)
 
-	// TODO(user): fill in your defaulting logic.
+	if ai.Spec.Astra.TokenRef == "" {
+		ai.Spec.Astra.TokenRef = common.DefaultTokenRef
+	}
+
+	if ai.Spec.ImageRegistry.Name == "" {
+		ai.Spec.ImageRegistry.Name = common.DefaultImageRegistry
+	}
+
+	if ai.Spec.NatsSyncClient.CloudBridgeURL == "" {
+		ai.Spec.NatsSyncClient.CloudBridgeURL = common.NatsSyncClientDefaultCloudBridgeURL
+	}
+
+	if ai.Spec.AstraConnect.Replicas == 0 {
+		ai.Spec.AstraConnect.Replicas = 1
+	}
+
+	ai.Spec.AstraConnect.ResourceRequirements = common.ResolveResourceRequirements(ai.Spec.AstraConnect.ResourceRequirements,
+		common.GetDefaultConnectorResources())
+	ai.Spec.Neptune.ResourceRequirements = common.ResolveResourceRequirements(ai.Spec.Neptune.ResourceRequirements,
+		common.GetDefaultNeptuneResources())
 }
 
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
@@ -41,21 +69,37 @@ var _ webhook.Validator = &AstraConnector{}
 func (ai *AstraConnector) ValidateCreate() (admission.Warnings, error) {
 	astraConnectorLog.Info("validate create", "name", ai.Name)
 
-	// TODO return errors from below
-	_ = ai.ValidateCreateAstraConnector()
-	return nil, nil
+	warnings := ai.GetValidationWarnings()
+	errs := append(ai.ValidateCreateAstraConnector(), ai.ValidateSpec()...)
+	if len(errs) != 0 {
+		return warnings, ai.invalidError(errs)
+	}
+	return warnings, nil
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (ai *AstraConnector) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	astraConnectorLog.Info("validate update", "name", ai.Name)
 
-	// TODO return errors from below
-	_ = ai.ValidateUpdateAstraConnector()
-	return nil, nil
+	warnings := ai.GetValidationWarnings()
+	errs := ai.ValidateSpec()
+	oldAstraConnector, ok := old.(*AstraConnector)
+	if !ok {
+		return warnings, apierrors.NewBadRequest(fmt.Sprintf("expected an AstraConnector but got a %T", old))
+	}
+	errs = append(errs, ai.ValidateUpdateAstraConnector(oldAstraConnector)...)
+	if len(errs) != 0 {
+		return warnings, ai.invalidError(errs)
+	}
+	return warnings, nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (ai *AstraConnector) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
+
+// invalidError wraps the field errors in an Invalid status error so that kubectl reports every failing field
+func (ai *AstraConnector) invalidError(errs field.ErrorList) error {
+	return apierrors.NewInvalid(GroupVersion.WithKind("AstraConnector").GroupKind(), ai.Name, errs)
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package v1_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/NetApp-Polaris/astra-connector-operator/common"
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+)
+
+func TestAstraConnector_Default(t *testing.T) {
+	t.Run("Default__EmptySpecIsPopulated", func(t *testing.T) {
+		ai := &v1.AstraConnector{}
+		ai.Default()
+
+		assert.Equal(t, common.DefaultTokenRef, ai.Spec.Astra.TokenRef)
+		assert.Equal(t, common.DefaultImageRegistry, ai.Spec.ImageRegistry.Name)
+		assert.Equal(t, common.NatsSyncClientDefaultCloudBridgeURL, ai.Spec.NatsSyncClient.CloudBridgeURL)
+		// Images are resolved by the deployers, so operator upgrades roll them
+		assert.Empty(t, ai.Spec.AstraConnect.Image)
+		assert.Equal(t, int32(1), ai.Spec.AstraConnect.Replicas)
+		assert.Empty(t, ai.Spec.Neptune.Image)
+		assert.Equal(t, common.GetDefaultConnectorResources(), ai.Spec.AstraConnect.ResourceRequirements)
+		assert.Equal(t, common.GetDefaultNeptuneResources(), ai.Spec.Neptune.ResourceRequirements)
+	})
+
+	t.Run("Default__UserValuesArePreserved", func(t *testing.T) {
+		limits := corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("3Gi")}
+		ai := &v1.AstraConnector{
+			Spec: v1.AstraConnectorSpec{
+				Astra:          v1.Astra{TokenRef: "my-token"},
+				ImageRegistry:  v1.ImageRegistry{Name: "my-registry"},
+				NatsSyncClient: v1.NatsSyncClient{CloudBridgeURL: "https://my-astra"},
+				AstraConnect: v1.AstraConnect{
+					Image:                "my-tag",
+					Replicas:             2,
+					ResourceRequirements: corev1.ResourceRequirements{Limits: limits},
+				},
+				Neptune: v1.Neptune{Image: "my-neptune-tag"},
+			},
+		}
+		ai.Default()
+
+		assert.Equal(t, "my-token", ai.Spec.Astra.TokenRef)
+		assert.Equal(t, "my-registry", ai.Spec.ImageRegistry.Name)
+		assert.Equal(t, "https://my-astra", ai.Spec.NatsSyncClient.CloudBridgeURL)
+		assert.Equal(t, "my-tag", ai.Spec.AstraConnect.Image)
+		assert.Equal(t, int32(2), ai.Spec.AstraConnect.Replicas)
+		assert.Equal(t, "my-neptune-tag", ai.Spec.Neptune.Image)
+
+		// Limits were set by the user, the requests are taken from them
+		assert.Equal(t, limits, ai.Spec.AstraConnect.ResourceRequirements.Limits)
+		assert.Equal(t, limits, ai.Spec.AstraConnect.ResourceRequirements.Requests)
+	})
+
+	t.Run("Default__LimitBelowDefaultRequestIsValid", func(t *testing.T) {
+		limits := corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")}
+		ai := createValidAstraConnector()
+		ai.Spec.AstraConnect.ResourceRequirements = corev1.ResourceRequirements{Limits: limits}
+		ai.Spec.Neptune.ResourceRequirements = corev1.ResourceRequirements{Limits: limits}
+		ai.Default()
+
+		assert.Equal(t, limits, ai.Spec.AstraConnect.ResourceRequirements.Requests)
+		assert.Equal(t, limits, ai.Spec.Neptune.ResourceRequirements.Requests)
+		_, err := ai.ValidateCreate()
+		assert.NoError(t, err)
+	})
+
+	t.Run("Default__RequestsOnlyAreNotLimited", func(t *testing.T) {
+		requests := corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")}
+		ai := createValidAstraConnector()
+		ai.Spec.AstraConnect.ResourceRequirements = corev1.ResourceRequirements{Requests: requests}
+		ai.Default()
+
+		assert.Nil(t, ai.Spec.AstraConnect.ResourceRequirements.Limits)
+		assert.Equal(t, requests, ai.Spec.AstraConnect.ResourceRequirements.Requests)
+		_, err := ai.ValidateCreate()
+		assert.NoError(t, err)
+	})
+}
@@ -10,12 +10,19 @@ Copyright 2022 NetApp, Inc. All Rights Reserved.
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Astra) DeepCopyInto(out *Astra) {
 	*out = *in
+	if in.CABundleRef != nil {
+		in, out := &in.CABundleRef, &out.CABundleRef
+		*out = new(CABundleRef)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Astra.
@@ -32,6 +39,7 @@ func (in *Astra) DeepCopy() *Astra {
 func (in *AstraConnect) DeepCopyInto(out *AstraConnect) {
 	*out = *in
 	in.ResourceRequirements.DeepCopyInto(&out.ResourceRequirements)
+	in.Scheduling.DeepCopyInto(&out.Scheduling)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AstraConnect.
@@ -50,7 +58,7 @@ func (in *AstraConnector) DeepCopyInto(out *AstraConnector) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AstraConnector.
@@ -106,13 +114,14 @@ func (in *AstraConnectorList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AstraConnectorSpec) DeepCopyInto(out *AstraConnectorSpec) {
 	*out = *in
-	out.Astra = in.Astra
+	in.Astra.DeepCopyInto(&out.Astra)
 	out.NatsSyncClient = in.NatsSyncClient
 	out.Nats = in.Nats
 	in.AstraConnect.DeepCopyInto(&out.AstraConnect)
 	in.Neptune.DeepCopyInto(&out.Neptune)
 	out.ImageRegistry = in.ImageRegistry
 	out.AutoSupport = in.AutoSupport
+	in.Precheck.DeepCopyInto(&out.Precheck)
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
 		*out = make(map[string]string, len(*in))
@@ -120,6 +129,11 @@ func (in *AstraConnectorSpec) DeepCopyInto(out *AstraConnectorSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(Proxy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AstraConnectorSpec.
@@ -136,6 +150,33 @@ func (in *AstraConnectorSpec) DeepCopy() *AstraConnectorSpec {
 func (in *AstraConnectorStatus) DeepCopyInto(out *AstraConnectorStatus) {
 	*out = *in
 	out.NatsSyncClient = in.NatsSyncClient
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Prechecks != nil {
+		in, out := &in.Prechecks, &out.Prechecks
+		*out = make([]PrecheckResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.Trident != nil {
+		in, out := &in.Trident, &out.Trident
+		*out = new(TridentStatus)
+		**out = **in
+	}
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(ClusterStatus)
+		**out = **in
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = new(ImagesStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AstraConnectorStatus.
@@ -163,6 +204,46 @@ func (in *AutoSupport) DeepCopy() *AutoSupport {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CABundleRef) DeepCopyInto(out *CABundleRef) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CABundleRef.
+func (in *CABundleRef) DeepCopy() *CABundleRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CABundleRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
+func (in *ClusterStatus) DeepCopy() *ClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageRegistry) DeepCopyInto(out *ImageRegistry) {
 	*out = *in
@@ -178,6 +259,21 @@ func (in *ImageRegistry) DeepCopy() *ImageRegistry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagesStatus) DeepCopyInto(out *ImagesStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagesStatus.
+func (in *ImagesStatus) DeepCopy() *ImagesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Nats) DeepCopyInto(out *Nats) {
 	*out = *in
@@ -227,6 +323,7 @@ func (in *NatsSyncClientStatus) DeepCopy() *NatsSyncClientStatus {
 func (in *Neptune) DeepCopyInto(out *Neptune) {
 	*out = *in
 	in.ResourceRequirements.DeepCopyInto(&out.ResourceRequirements)
+	in.Scheduling.DeepCopyInto(&out.Scheduling)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Neptune.
@@ -238,3 +335,109 @@ func (in *Neptune) DeepCopy() *Neptune {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Precheck) DeepCopyInto(out *Precheck) {
+	*out = *in
+	if in.Skip != nil {
+		in, out := &in.Skip, &out.Skip
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Precheck.
+func (in *Precheck) DeepCopy() *Precheck {
+	if in == nil {
+		return nil
+	}
+	out := new(Precheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrecheckResult) DeepCopyInto(out *PrecheckResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrecheckResult.
+func (in *PrecheckResult) DeepCopy() *PrecheckResult {
+	if in == nil {
+		return nil
+	}
+	out := new(PrecheckResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Proxy) DeepCopyInto(out *Proxy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Proxy.
+func (in *Proxy) DeepCopy() *Proxy {
+	if in == nil {
+		return nil
+	}
+	out := new(Proxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Scheduling) DeepCopyInto(out *Scheduling) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Scheduling.
+func (in *Scheduling) DeepCopy() *Scheduling {
+	if in == nil {
+		return nil
+	}
+	out := new(Scheduling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TridentStatus) DeepCopyInto(out *TridentStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TridentStatus.
+func (in *TridentStatus) DeepCopy() *TridentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TridentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -6,7 +6,6 @@ package controllers
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -16,23 +15,31 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/NetApp-Polaris/astra-connector-operator/app/conf"
+	"github.com/NetApp-Polaris/astra-connector-operator/app/metrics"
 	"github.com/NetApp-Polaris/astra-connector-operator/app/register"
+	"github.com/NetApp-Polaris/astra-connector-operator/common"
 	"github.com/NetApp-Polaris/astra-connector-operator/details/k8s"
 	"github.com/NetApp-Polaris/astra-connector-operator/details/k8s/precheck"
 	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
@@ -44,15 +51,29 @@ type AstraConnectorController struct {
 	*kubernetes.Clientset
 	Scheme        *runtime.Scheme
 	DynamicClient dynamic.Interface
+	// Recorder records the lifecycle events of the AstraConnector
+	Recorder record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=astra.netapp.io,resources=astraconnectors,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=astra.netapp.io,resources=astraconnectors/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=astra.netapp.io,resources=astraconnectors/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=*,resources=*,verbs=*
-// +kubebuilder:rbac:groups="";apiextensions.k8s.io;apps;autoscaling;batch;crd.projectcalico.org;extensions;networking.k8s.io;policy;rbac.authorization.k8s.io;security.openshift.io;snapshot.storage.k8s.io;storage.k8s.io;trident.netapp.io,resources=configmaps;cronjobs;customresourcedefinitions;daemonsets;deployments;horizontalpodautoscalers;ingresses;jobs;namespaces;networkpolicies;persistentvolumeclaims;poddisruptionbudgets;pods;podtemplates;podsecuritypolicies;replicasets;replicationcontrollers;replicationcontrollers/scale;rolebindings;roles;secrets;serviceaccounts;services;statefulsets;storageclasses;csidrivers;csinodes;securitycontextconstraints;tridentmirrorrelationships;tridentsnapshotinfos;tridentvolumes;volumesnapshots;volumesnapshotcontents;tridentversions;tridentbackends;tridentnodes,verbs=get;list;watch;delete;use;create;update;patch
+// +kubebuilder:rbac:groups="";apiextensions.k8s.io;apps;autoscaling;batch;crd.projectcalico.org;extensions;networking.k8s.io;policy;rbac.authorization.k8s.io;security.openshift.io;snapshot.storage.k8s.io;storage.k8s.io;trident.netapp.io,resources=configmaps;cronjobs;customresourcedefinitions;daemonsets;deployments;horizontalpodautoscalers;ingresses;jobs;namespaces;networkpolicies;persistentvolumeclaims;poddisruptionbudgets;pods;podtemplates;podsecuritypolicies;replicasets;replicationcontrollers;replicationcontrollers/scale;rolebindings;roles;secrets;serviceaccounts;services;statefulsets;storageclasses;csidrivers;csinodes;securitycontextconstraints;tridentmirrorrelationships;tridentsnapshotinfos;tridentvolumes;volumesnapshots;volumesnapshotcontents;tridentversions;tridentorchestrators;tridentbackends;tridentnodes,verbs=get;list;watch;delete;use;create;update;patch
 // +kubebuilder:rbac:urls=/metrics,verbs=get;list;watch
 
+// Reconcile phases reported in the reconcile_phase_duration_seconds metric
+const (
+	phaseValidate        = "validate"
+	phasePrecheck        = "precheck"
+	phaseDeployNeptune   = "deploy_neptune"
+	phaseDeployConnector = "deploy_connector"
+	phaseClusterManaged  = "cluster_managed"
+	phaseASUP            = "asup"
+	phaseUnregister      = "unregister"
+)
+
 func (r *AstraConnectorController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := ctrllog.FromContext(ctx)
 
@@ -65,6 +86,7 @@ func (r *AstraConnectorController) Reconcile(ctx context.Context, req ctrl.Reque
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
 			log.Info("AstraConnector resource not found. Ignoring since object must be deleted")
+			metrics.DeleteConnector(req.Namespace, req.Name)
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -84,21 +106,32 @@ func (r *AstraConnectorController) Reconcile(ctx context.Context, req ctrl.Reque
 		natsSyncClientStatus.Registered = "false"
 	}
 
-	// Validate AstraConnector CR for any errors
-	err = r.validateAstraConnector(*astraConnector, log)
-	if err != nil {
-		// Error validating the connector object. Do not requeue and update the connector status.
-		log.Error(err, FailedAstraConnectorValidation)
-		natsSyncClientStatus.Status = fmt.Sprintf("%s; %s", FailedAstraConnectorValidation, err.Error())
-		_ = r.updateAstraConnectorStatus(ctx, astraConnector, natsSyncClientStatus)
-		// Do not requeue. This is a user input error
-		return ctrl.Result{}, err
+	// A paused AstraConnector is left alone, even when it is being deleted, until the annotation is removed
+	if astraConnector.IsPaused() {
+		return r.reconcilePaused(ctx, astraConnector, &natsSyncClientStatus)
 	}
+	r.resumeReconcile(ctx, astraConnector)
+
+	var phaseStart time.Time
 
 	// name of our custom finalizer
 	finalizerName := "netapp.io/finalizer"
 	// examine DeletionTimestamp to determine if object is under deletion
 	if astraConnector.ObjectMeta.DeletionTimestamp.IsZero() {
+		// Validate AstraConnector CR for any errors, a CR being deleted is never blocked by validation
+		phaseStart = time.Now()
+		err = r.validateAstraConnector(*astraConnector, log)
+		metrics.ObserveReconcilePhase(phaseValidate, phaseStart, err)
+		if err != nil {
+			// Error validating the connector object. Do not requeue and update the connector status.
+			log.Error(err, FailedAstraConnectorValidation)
+			natsSyncClientStatus.Status = fmt.Sprintf("%s; %s", FailedAstraConnectorValidation, err.Error())
+			astraConnector.SetCondition(v1.ConditionReady, metav1.ConditionFalse, v1.ReasonValidationFailed, err.Error())
+			_ = r.updateAstraConnectorStatus(ctx, astraConnector, natsSyncClientStatus)
+			// Do not requeue. This is a user input error
+			return ctrl.Result{}, err
+		}
+
 		// The object is not being deleted, so if it does not have our finalizer,
 		// then lets add the finalizer and update the object. This is equivalent
 		// registering our finalizer.
@@ -107,6 +140,7 @@ func (r *AstraConnectorController) Reconcile(ctx context.Context, req ctrl.Reque
 			controllerutil.AddFinalizer(astraConnector, finalizerName)
 			if err := r.Update(ctx, astraConnector); err != nil {
 				natsSyncClientStatus.Status = FailedFinalizerAdd
+				astraConnector.SetCondition(v1.ConditionReady, metav1.ConditionFalse, v1.ReasonFinalizerAddFailed, err.Error())
 				_ = r.updateAstraConnectorStatus(ctx, astraConnector, natsSyncClientStatus)
 				return ctrl.Result{}, err
 			}
@@ -117,9 +151,31 @@ func (r *AstraConnectorController) Reconcile(ctx context.Context, req ctrl.Reque
 		// The object is being deleted
 		if controllerutil.ContainsFinalizer(astraConnector, finalizerName) {
 			// Update status message to indicate that CR delete is in progress
+			if !astraConnector.IsConditionTrue(v1.ConditionDeleting) {
+				r.recordEvent(astraConnector, EventReasonDeleting, DeleteInProgress)
+			}
 			natsSyncClientStatus.Status = DeleteInProgress
+			astraConnector.SetCondition(v1.ConditionDeleting, metav1.ConditionTrue, v1.ReasonDeletionInProgress, DeleteInProgress)
+			astraConnector.SetCondition(v1.ConditionReady, metav1.ConditionFalse, v1.ReasonDeletionInProgress, DeleteInProgress)
 			_ = r.updateAstraConnectorStatus(ctx, astraConnector, natsSyncClientStatus)
 
+			// unmanage the cluster in Astra so it does not linger as a ghost managed cluster
+			phaseStart = time.Now()
+			err := r.unregisterCluster(ctx, astraConnector, &natsSyncClientStatus)
+			metrics.ObserveReconcilePhase(phaseUnregister, phaseStart, err)
+			if err != nil {
+				if time.Since(astraConnector.DeletionTimestamp.Time) < conf.Config.UnregisterTimeout() {
+					log.Error(err, "Failed to unregister cluster, requeueing after delay", "delay", conf.Config.ErrorTimeout())
+					_ = r.updateAstraConnectorStatus(ctx, astraConnector, natsSyncClientStatus)
+					return ctrl.Result{RequeueAfter: time.Second * conf.Config.ErrorTimeout()}, nil
+				}
+				log.Error(err, "Timed out unregistering cluster, continuing with deletion", "timeout", conf.Config.UnregisterTimeout())
+				r.recordWarning(astraConnector, EventReasonUnregisterFailed, "%s, continuing with deletion", ErrorUnregisterTimedOut)
+				astraConnector.SetCondition(v1.ConditionUnregistered, metav1.ConditionFalse, v1.ReasonUnregisterTimedOut,
+					fmt.Sprintf("%s: %s", ErrorUnregisterTimedOut, err.Error()))
+				_ = r.updateAstraConnectorStatus(ctx, astraConnector, natsSyncClientStatus)
+			}
+
 			// delete any cluster scoped resources created by the operator
 			r.deleteConnectorClusterScopedResources(ctx, astraConnector)
 
@@ -127,6 +183,7 @@ func (r *AstraConnectorController) Reconcile(ctx context.Context, req ctrl.Reque
 			controllerutil.RemoveFinalizer(astraConnector, finalizerName)
 			if err := r.Update(ctx, astraConnector); err != nil {
 				natsSyncClientStatus.Status = FailedFinalizerRemove
+				astraConnector.SetCondition(v1.ConditionDeleting, metav1.ConditionFalse, v1.ReasonFinalizerRemoveFailed, err.Error())
 				_ = r.updateAstraConnectorStatus(ctx, astraConnector, natsSyncClientStatus)
 				// Do not requeue. Item is being deleted
 				return ctrl.Result{}, err
@@ -135,6 +192,8 @@ func (r *AstraConnectorController) Reconcile(ctx context.Context, req ctrl.Reque
 			// Update status message to indicate that CR delete is in finished
 			natsSyncClientStatus.Status = DeletionComplete
 			_ = r.updateAstraConnectorStatus(ctx, astraConnector, natsSyncClientStatus)
+			metrics.DeleteConnector(astraConnector.Namespace, astraConnector.Name)
+			r.recordEvent(astraConnector, EventReasonDeleted, DeletionComplete)
 		}
 
 		// Stop reconciliation as the item is being deleted
@@ -142,39 +201,88 @@ func (r *AstraConnectorController) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, nil
 	}
 
-	if !astraConnector.Spec.SkipPreCheck {
-		k8sUtil := k8s.NewK8sUtil(r.Client, r.Clientset, log)
-		preCheckClient := precheck.NewPrecheckClient(log, k8sUtil)
-		errList := preCheckClient.Run()
+	if astraConnector.Spec.Astra.Unregister {
+		return r.reconcileUnregister(ctx, astraConnector, &natsSyncClientStatus)
+	}
+	// Unregister was turned off, so a future unregister request must call Astra again
+	astraConnector.RemoveCondition(v1.ConditionUnregistered)
 
-		if errList != nil {
-			errString := ""
-			for i, err := range errList {
-				if i > 0 {
-					errString = errString + ", "
-				}
+	// A new generation is being rolled out, so Ready no longer reflects the current spec
+	if readyCondition := astraConnector.GetCondition(v1.ConditionReady); readyCondition == nil ||
+		readyCondition.ObservedGeneration != astraConnector.Generation {
+		astraConnector.SetCondition(v1.ConditionReady, metav1.ConditionFalse, v1.ReasonReconciling, "Reconciling AstraConnector")
+	}
 
-				log.Error(err, "Pre-check Error")
-				errString = errString + err.Error()
-			}
-			errString = "Pre-check errors: " + errString
+	k8sUtil := k8s.NewK8sUtil(r.Client, r.Clientset, log)
+	detectCluster(astraConnector, k8sUtil, k8s.NewClusterTypeChecker(k8sUtil, log), log)
+	astraConnector.Status.Images = getEffectiveImages(astraConnector)
+
+	if astraConnector.Spec.SkipPreCheck {
+		astraConnector.Status.Prechecks = nil
+		astraConnector.Status.Trident = nil
+		astraConnector.SetCondition(v1.ConditionPrecheckPassed, metav1.ConditionTrue, v1.ReasonPrecheckSkipped, "Pre-checks skipped")
+	} else {
+		preCheckClient := precheck.NewPrecheckClient(log, k8sUtil)
+		if astraConnector.Status.Cluster != nil {
+			preCheckClient.SetClusterFlavor(astraConnector.Status.Cluster.Flavor)
+		}
+		phaseStart = time.Now()
+		results := preCheckClient.Run(astraConnector.Spec.Precheck.Skip)
+		astraConnector.Status.Prechecks = results
+		astraConnector.Status.Trident = preCheckClient.Trident()
+		errList := precheck.Failures(results, v1.PrecheckSeverityError)
+		var precheckErr error
+		if len(errList) > 0 {
+			precheckErr = errors.New(errList[0])
+		}
+		metrics.ObserveReconcilePhase(phasePrecheck, phaseStart, precheckErr)
+
+		if len(errList) > 0 {
+			errString := "Pre-check errors: " + strings.Join(errList, ", ")
+			log.Error(errors.New(errString), "Pre-check Error")
 			natsSyncClientStatus.Status = errString
+			r.recordWarning(astraConnector, EventReasonPrecheckFailed, "%s", errString)
+			astraConnector.SetCondition(v1.ConditionPrecheckPassed, metav1.ConditionFalse, v1.ReasonPrecheckFailed, errString)
+			astraConnector.SetCondition(v1.ConditionReady, metav1.ConditionFalse, v1.ReasonPrecheckFailed, errString)
 			_ = r.updateAstraConnectorStatus(ctx, astraConnector, natsSyncClientStatus)
 			// Do not requeue. Item is being deleted
 			return ctrl.Result{}, errors.New(errString)
 		}
+
+		// Warnings do not block the installation, they are surfaced in the condition and as events
+		if warnings := precheck.Failures(results, v1.PrecheckSeverityWarning); len(warnings) > 0 {
+			warnString := "Pre-check warnings: " + strings.Join(warnings, ", ")
+			log.Info(warnString)
+			r.recordWarning(astraConnector, EventReasonPrecheckWarning, "%s", warnString)
+			astraConnector.SetCondition(v1.ConditionPrecheckPassed, metav1.ConditionTrue, v1.ReasonPrecheckWarnings, warnString)
+		} else {
+			astraConnector.SetCondition(v1.ConditionPrecheckPassed, metav1.ConditionTrue, v1.ReasonPrecheckSucceeded, "All pre-checks passed")
+		}
 	}
 
 	// deploy Neptune
 	if conf.Config.FeatureFlags().DeployNeptune() {
 		log.Info("Initiating Neptune deployment")
+		phaseStart = time.Now()
 		neptuneResult, err := r.deployNeptune(ctx, astraConnector, &natsSyncClientStatus)
+		metrics.ObserveReconcilePhase(phaseDeployNeptune, phaseStart, err)
 		if err != nil {
 			// Note: Returning nil in error since we want to wait for the requeue to happen
 			// non nil errors triggers the requeue right away
 			log.Error(err, "Error deploying Neptune, requeueing after delay", "delay", conf.Config.ErrorTimeout())
+			astraConnector.SetCondition(v1.ConditionNeptuneDeployed, metav1.ConditionFalse, v1.ReasonDeployFailed, err.Error())
+			astraConnector.SetCondition(v1.ConditionReady, metav1.ConditionFalse, v1.ReasonDeployFailed, err.Error())
+			_ = r.updateAstraConnectorStatus(ctx, astraConnector, natsSyncClientStatus)
 			return neptuneResult, nil
 		}
+		if !neptuneResult.IsZero() {
+			// Neptune is still rolling out, the reconcile is requeued to check on it again
+			log.Info("Neptune is not ready yet, requeueing", "delay", neptuneResult.RequeueAfter)
+			setDeployInProgressCondition(astraConnector, v1.ConditionNeptuneDeployed, &natsSyncClientStatus)
+			_ = r.updateAstraConnectorStatus(ctx, astraConnector, natsSyncClientStatus)
+			return neptuneResult, nil
+		}
+		astraConnector.SetCondition(v1.ConditionNeptuneDeployed, metav1.ConditionTrue, v1.ReasonDeploySucceeded, "Neptune deployed")
 	}
 
 	if conf.Config.FeatureFlags().DeployNatsConnector() {
@@ -182,39 +290,86 @@ func (r *AstraConnectorController) Reconcile(ctx context.Context, req ctrl.Reque
 		var connectorResults ctrl.Result
 		var deployError error
 
+		phaseStart = time.Now()
 		connectorResults, deployError = r.deployNatlessConnector(ctx, astraConnector, &natsSyncClientStatus)
+		metrics.ObserveReconcilePhase(phaseDeployConnector, phaseStart, deployError)
+		connectorRollingOut := deployError == nil && !connectorResults.IsZero()
+		if deployError != nil {
+			astraConnector.SetCondition(v1.ConditionConnectorDeployed, metav1.ConditionFalse, v1.ReasonDeployFailed, deployError.Error())
+		} else if connectorRollingOut {
+			setDeployInProgressCondition(astraConnector, v1.ConditionConnectorDeployed, &natsSyncClientStatus)
+		} else {
+			astraConnector.SetCondition(v1.ConditionConnectorDeployed, metav1.ConditionTrue, v1.ReasonDeploySucceeded, "Astra Connector deployed")
+		}
 
-		// Wait for the cluster to become managed (aka "registered")
-		natsSyncClientStatus.Status = WaitForClusterManagedState
-		_ = r.updateAstraConnectorStatus(ctx, astraConnector, natsSyncClientStatus)
-		isManaged, err := waitForManagedCluster(astraConnector, r.Client, log)
+		// Check once whether the cluster is managed (aka "registered"), the reconcile is requeued until it is
+		phaseStart = time.Now()
+		isManaged, err := r.isClusterManaged(ctx, astraConnector)
+		metrics.ObserveReconcilePhase(phaseClusterManaged, phaseStart, err)
+		metrics.SetConnectorRegistered(astraConnector.Namespace, astraConnector.Name, isManaged)
 		if !isManaged {
-			log.Error(err, "timed out waiting for cluster to become managed, requeueing after delay", "delay", conf.Config.ErrorTimeout())
-			natsSyncClientStatus.Status = ErrorClusterUnmanaged
+			statusMsg := WaitForClusterManagedState
+			if err != nil {
+				log.Error(err, "encountered error while checking for cluster management")
+				statusMsg = fmt.Sprintf("%s: %s", ErrorClusterUnmanaged, err.Error())
+			}
+			log.Info("cluster not yet managed, requeueing after delay", "delay", conf.Config.ErrorTimeout())
+			if astraConnector.IsConditionTrue(v1.ConditionClusterManaged) {
+				r.recordWarning(astraConnector, EventReasonClusterUnmanaged, "%s", statusMsg)
+			}
+			if !connectorRollingOut {
+				natsSyncClientStatus.Status = statusMsg
+			}
+			astraConnector.SetCondition(v1.ConditionClusterManaged, metav1.ConditionFalse, v1.ReasonClusterUnmanaged, statusMsg)
+			astraConnector.SetCondition(v1.ConditionReady, metav1.ConditionFalse, v1.ReasonClusterUnmanaged, statusMsg)
 			_ = r.updateAstraConnectorStatus(ctx, astraConnector, natsSyncClientStatus)
-			// Do not wait 5min, wait 5sec before requeue instead since we have already been waiting in waitForManagedCluster
 			return ctrl.Result{RequeueAfter: time.Second * conf.Config.ErrorTimeout()}, nil
 		}
 		log.Info("Cluster is managed")
+		if isFirstTimeManaged(astraConnector) {
+			metrics.TimeToManaged.Observe(time.Since(astraConnector.CreationTimestamp.Time).Seconds())
+		}
+		if !astraConnector.IsConditionTrue(v1.ConditionClusterManaged) {
+			r.recordEvent(astraConnector, EventReasonClusterManaged, "Cluster %s is managed by Astra", astraConnector.Spec.Astra.ClusterId)
+		}
+		astraConnector.SetCondition(v1.ConditionClusterManaged, metav1.ConditionTrue, v1.ReasonClusterManaged, "Cluster is managed by Astra")
 
 		// ASUP Setup
+		phaseStart = time.Now()
 		err = r.createASUPCR(ctx, astraConnector, astraConnector.Spec.Astra.ClusterId)
+		metrics.ObserveReconcilePhase(phaseASUP, phaseStart, err)
 		if err != nil {
 			log.Error(err, FailedASUPCreation)
+			r.recordWarning(astraConnector, EventReasonASUPFailed, "%s: %v", FailedASUPCreation, err)
 			natsSyncClientStatus.Status = FailedASUPCreation
+			astraConnector.SetCondition(v1.ConditionASUPConfigured, metav1.ConditionFalse, v1.ReasonASUPCreateFailed, err.Error())
+			astraConnector.SetCondition(v1.ConditionReady, metav1.ConditionFalse, v1.ReasonASUPCreateFailed, err.Error())
 			_ = r.updateAstraConnectorStatus(ctx, astraConnector, natsSyncClientStatus)
 			return ctrl.Result{RequeueAfter: time.Minute * conf.Config.ErrorTimeout()}, err
 		}
 
+		astraConnector.SetCondition(v1.ConditionASUPConfigured, metav1.ConditionTrue, v1.ReasonASUPCreated, "AutoSupportBundleSchedule configured")
+
 		natsSyncClientStatus.Registered = "true"
 		natsSyncClientStatus.AstraClusterId = astraConnector.Spec.Astra.ClusterId
 		natsSyncClientStatus.Status = RegisteredWithAstra
 		_ = r.updateAstraConnectorStatus(ctx, astraConnector, natsSyncClientStatus)
+		if err := r.removeReregisterAnnotation(ctx, astraConnector); err != nil {
+			log.Error(err, "Failed to remove annotation", "annotation", v1.ReregisterAnnotation)
+		}
 
 		if deployError != nil {
 			// Note: Returning nil in error since we want to wait for the requeue to happen
 			// non nil errors triggers the requeue right away
-			log.Error(err, "Error deploying NatsConnector, requeueing after delay", "delay", conf.Config.ErrorTimeout())
+			log.Error(deployError, "Error deploying NatsConnector, requeueing after delay", "delay", conf.Config.ErrorTimeout())
+			astraConnector.SetCondition(v1.ConditionReady, metav1.ConditionFalse, v1.ReasonDeployFailed, deployError.Error())
+			_ = r.updateAstraConnectorStatus(ctx, astraConnector, natsSyncClientStatus)
+			return connectorResults, nil
+		}
+
+		if connectorRollingOut {
+			// Ready was already set to reflect the rollout, check on it again later
+			log.Info("Astra Connector is not ready yet, requeueing", "delay", connectorResults.RequeueAfter)
 			return connectorResults, nil
 		}
 	}
@@ -223,11 +378,9 @@ func (r *AstraConnectorController) Reconcile(ctx context.Context, req ctrl.Reque
 		log.Info(fmt.Sprintf("Updating CR status, clusterID: '%s'", natsSyncClientStatus.AstraClusterId))
 	}
 
+	astraConnector.SetCondition(v1.ConditionReady, metav1.ConditionTrue, v1.ReasonReconcileSucceeded, DeployedComponents)
+	astraConnector.Status.ObservedGeneration = astraConnector.Generation
 	_ = r.updateAstraConnectorStatus(ctx, astraConnector, natsSyncClientStatus)
-	err = r.waitForStatusUpdate(astraConnector, log)
-	if err != nil {
-		log.Error(err, "Failed to update status, ignoring since this will be fixed on a future reconcile.")
-	}
 
 	return ctrl.Result{}, nil
 }
@@ -252,18 +405,52 @@ func (r *AstraConnectorController) updateAstraConnectorStatus(
 }
 
 // SetupWithManager sets up the controller with the Manager.
+// Resources created by the Deployers are watched as well, so edits or deletes of them are reverted by a reconcile.
 func (r *AstraConnectorController) SetupWithManager(mgr ctrl.Manager) error {
+	// Deployment and StatefulSet status is updated constantly, only react to spec changes and deletes
+	specChanged := builder.WithPredicates(predicate.GenerationChangedPredicate{})
+	// Cluster scoped resources cannot be owned by the AstraConnector, they are labeled with their owner instead
+	ownerLabeled := builder.WithPredicates(predicate.NewPredicateFuncs(hasOwnerLabels))
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&v1.AstraConnector{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		For(&v1.AstraConnector{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, pausedChanged))).
+		Owns(&corev1.ConfigMap{}).
+		Owns(&corev1.ServiceAccount{}).
+		Owns(&corev1.Service{}).
+		Owns(&rbacv1.Role{}).
+		Owns(&rbacv1.RoleBinding{}).
+		Owns(&appsv1.StatefulSet{}, specChanged).
+		Owns(&appsv1.Deployment{}, specChanged).
+		Watches(&rbacv1.ClusterRole{}, handler.EnqueueRequestsFromMapFunc(ownerLabelsToRequests), ownerLabeled).
+		Watches(&rbacv1.ClusterRoleBinding{}, handler.EnqueueRequestsFromMapFunc(ownerLabelsToRequests), ownerLabeled).
 		WithOptions(controller.Options{MaxConcurrentReconciles: 1}).
 		Complete(r)
 }
 
+// hasOwnerLabels returns true if the object was created by an AstraConnector and labeled with its owner
+func hasOwnerLabels(obj client.Object) bool {
+	labels := obj.GetLabels()
+	return labels[common.OwnerNameLabel] != "" && labels[common.OwnerNamespaceLabel] != ""
+}
+
+// ownerLabelsToRequests maps a labeled cluster scoped object to a reconcile of the AstraConnector that created it
+func ownerLabelsToRequests(_ context.Context, obj client.Object) []reconcile.Request {
+	if !hasOwnerLabels(obj) {
+		return nil
+	}
+	labels := obj.GetLabels()
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{Name: labels[common.OwnerNameLabel], Namespace: labels[common.OwnerNamespaceLabel]},
+	}}
+}
+
 func (r *AstraConnectorController) validateAstraConnector(connector v1.AstraConnector, logger logr.Logger) error {
 	var validateErrors field.ErrorList
 
 	logger.V(3).Info("Validating Create AstraConnector")
 	validateErrors = connector.ValidateCreateAstraConnector()
+	validateErrors = append(validateErrors, connector.ValidateSpec()...)
+	validateErrors = append(validateErrors, connector.ValidateRegisteredIdentity())
 
 	var fieldErrors []string
 	for _, v := range validateErrors {
@@ -280,77 +467,61 @@ func (r *AstraConnectorController) validateAstraConnector(connector v1.AstraConn
 	return errors.New(fmt.Sprintf("Errors while validating AstraConnector CR: %s", strings.Join(fieldErrors, "; ")))
 }
 
-// waitForStatusUpdate waits for the status of an AstraConnector to be updated in Kubernetes.
-// Status().Update() function returns even though the update is still in progress on k8s.
-// Polling to make sure this function exits only after status subresource update is reflected in k8s.
-func (r *AstraConnectorController) waitForStatusUpdate(astraConnector *v1.AstraConnector, log logr.Logger) error {
-	interval := 2 * time.Second
-	timeout := 15 * time.Second
-
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-
-	// Start polling process
-	err := errors.Wrap(wait.PollUntilContextTimeout(ctx, interval, timeout, true,
-		func(ctx context.Context) (bool, error) {
-			current := &v1.AstraConnector{}
-			err := r.Get(ctx, types.NamespacedName{Name: astraConnector.Name, Namespace: astraConnector.Namespace}, current)
-			if err != nil {
-				log.Error(err, "Failed to get the current status of the AstraConnector. Retrying...")
-				return false, nil
-			}
-
-			astraConnectorStatusJson, err := json.Marshal(astraConnector.Status)
-			if err != nil {
-				log.Error(err, "Failed to marshal astraConnector.Status to JSON")
-				return false, nil
-			}
-
-			currentStatusJson, err := json.Marshal(current.Status)
-			if err != nil {
-				log.Error(err, "Failed to marshal current.Status to JSON")
-				return false, nil
-			}
+// removeReregisterAnnotation removes the reregister annotation once the cluster is registered with the new identity,
+// so a later identity change has to be allowed explicitly again
+func (r *AstraConnectorController) removeReregisterAnnotation(ctx context.Context, astraConnector *v1.AstraConnector) error {
+	registeredClusterId := astraConnector.Status.NatsSyncClient.AstraClusterId
+	if !astraConnector.IsReregisterRequested() || registeredClusterId == "" || registeredClusterId != astraConnector.Spec.Astra.ClusterId {
+		return nil
+	}
 
-			// If the status has not been updated yet, log the current and expected statuses and continue polling.
-			if string(astraConnectorStatusJson) != string(currentStatusJson) {
-				log.Info("AstraConnector instance status subresource update is in progress... retrying",
-					"Expected status", astraConnector.Status, "Actual status", current.Status)
-				return false, nil
-			}
+	patch := client.MergeFrom(astraConnector.DeepCopy())
+	delete(astraConnector.Annotations, v1.ReregisterAnnotation)
+	return r.Patch(ctx, astraConnector, patch)
+}
 
-			// Otherwise stop polling
-			return true, nil
-		}), fmt.Sprintf("AstraConnector status is not updated even after %s", timeout))
+// isFirstTimeManaged returns true if the cluster of the AstraConnector was never managed before. A cluster that was
+// registered, or found managed by a reconcile that was then requeued e.g. because the ASUP CR failed, is not counted
+// again in the TimeToManaged metric.
+func isFirstTimeManaged(astraConnector *v1.AstraConnector) bool {
+	return astraConnector.Status.NatsSyncClient.AstraClusterId == "" &&
+		!astraConnector.IsConditionTrue(v1.ConditionClusterManaged)
+}
 
-	if err == nil {
-		log.Info("AstraConnector status reflected in k8s")
+// getEffectiveImages Returns the images of the components enabled by the feature flags, the images the spec leaves
+// empty are not defaulted by the webhook so an operator upgrade rolls them, the status shows what they resolve to
+func getEffectiveImages(astraConnector *v1.AstraConnector) *v1.ImagesStatus {
+	images := &v1.ImagesStatus{}
+	if conf.Config.FeatureFlags().DeployNatsConnector() {
+		images.Connector = astraConnector.GetConnectorImage()
+	}
+	if conf.Config.FeatureFlags().DeployNeptune() {
+		images.Neptune = astraConnector.GetNeptuneImage()
 	}
-	return err
+	return images
 }
 
-func waitForManagedCluster(astraConnector *v1.AstraConnector, client client.Client, log logr.Logger) (bool, error) {
-	registerUtil := register.NewClusterRegisterUtil(astraConnector, &http.Client{}, client, nil, log, context.Background())
+// newClusterRegisterUtil Returns a ClusterRegisterUtil with its own HTTP client, CloseIdleConnections must be called once done
+func newClusterRegisterUtil(ctx context.Context, astraConnector *v1.AstraConnector, client client.Client, log logr.Logger) (register.ClusterRegisterUtil, error) {
+	registerUtil := register.NewClusterRegisterUtil(astraConnector, &http.Client{}, client, nil, log, ctx)
 	// SetHttpClient should be in the New func above but would require a larger refactor
 	// Setup TLS if enabled, setup hostAliasIP if used
 	err := registerUtil.SetHttpClient(astraConnector.Spec.Astra.SkipTLSValidation, register.GetAstraHostURL(astraConnector))
 	if err != nil {
-		return false, fmt.Errorf("failed to setup HTTP client: %w", err)
+		return nil, fmt.Errorf("failed to setup HTTP client: %w", err)
 	}
+	return registerUtil, nil
+}
 
-	maxRetries := 5
-	waitTime := 3 * time.Second
-	var isManaged bool
-	for i := 1; i <= maxRetries; i++ {
-		isManaged, _, err = registerUtil.IsClusterManaged()
-		if isManaged {
-			break
-		}
-		if err != nil {
-			log.Error(err, "encountered error while checking for cluster management")
-		}
-		log.Info("cluster not yet managed", "retiresLeft", maxRetries-i)
-		time.Sleep(waitTime)
+// isClusterManaged Asks Astra once whether the cluster is managed, the caller requeues instead of polling
+func (r *AstraConnectorController) isClusterManaged(ctx context.Context, astraConnector *v1.AstraConnector) (bool, error) {
+	log := ctrllog.FromContext(ctx)
+	registerUtil, err := newClusterRegisterUtil(ctx, astraConnector, r.Client, log)
+	if err != nil {
+		return false, err
 	}
+	defer registerUtil.CloseIdleConnections()
+
+	isManaged, _, err := registerUtil.IsClusterManaged()
 	return isManaged, err
 }
@@ -4,6 +4,25 @@
 
 package controllers
 
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/NetApp-Polaris/astra-connector-operator/common"
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+)
+
 // TODO not valid missing required fields
 // Operator is being replaced will fix with new one - Oscar
 
@@ -170,3 +189,169 @@ package controllers
 //	err = controller.validateAstraConnector(connector, log)
 //	assert.NoError(t, err)
 //}
+
+func TestReconcileDeletingInvalidConnector(t *testing.T) {
+	// Neither the namespace nor the missing tokenRef may block the deletion
+	astraConnector := newEventsAstraConnector()
+	astraConnector.Namespace = "default"
+	astraConnector.Spec.Astra.ClusterId = ""
+	astraConnector.Finalizers = []string{"netapp.io/finalizer"}
+	now := metav1.Now()
+	astraConnector.DeletionTimestamp = &now
+	scheme := runtime.NewScheme()
+	assert.NoError(t, v1.AddToScheme(scheme))
+	assert.NoError(t, rbacv1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(astraConnector).
+		WithStatusSubresource(astraConnector).Build()
+	r := &AstraConnectorController{Client: fakeClient, Recorder: record.NewFakeRecorder(20)}
+	ctx := context.Background()
+	request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(astraConnector)}
+
+	result, err := r.Reconcile(ctx, request)
+	assert.NoError(t, err)
+	assert.True(t, result.IsZero())
+
+	// The finalizer was removed, so the object is gone
+	err = fakeClient.Get(ctx, request.NamespacedName, &v1.AstraConnector{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestReconcileInvalidSpecIsReported(t *testing.T) {
+	// The webhook is disabled by default, the controller reports what it would have rejected
+	astraConnector := newEventsAstraConnector()
+	scheme := runtime.NewScheme()
+	assert.NoError(t, v1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(astraConnector).
+		WithStatusSubresource(astraConnector).Build()
+	r := &AstraConnectorController{Client: fakeClient, Recorder: record.NewFakeRecorder(20)}
+	ctx := context.Background()
+	request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(astraConnector)}
+
+	_, err := r.Reconcile(ctx, request)
+	assert.ErrorContains(t, err, "spec.astra.tokenRef")
+
+	updated := &v1.AstraConnector{}
+	assert.NoError(t, fakeClient.Get(ctx, request.NamespacedName, updated))
+	ready := updated.GetCondition(v1.ConditionReady)
+	if assert.NotNil(t, ready) {
+		assert.Equal(t, metav1.ConditionFalse, ready.Status)
+		assert.Equal(t, v1.ReasonValidationFailed, ready.Reason)
+		assert.Contains(t, ready.Message, "spec.astra.tokenRef")
+	}
+	assert.Empty(t, updated.Finalizers)
+}
+
+func TestRemoveReregisterAnnotation(t *testing.T) {
+	newController := func(astraConnector *v1.AstraConnector) (*AstraConnectorController, client.Client) {
+		scheme := runtime.NewScheme()
+		assert.NoError(t, v1.AddToScheme(scheme))
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(astraConnector).Build()
+		return &AstraConnectorController{Client: fakeClient}, fakeClient
+	}
+
+	t.Run("RemoveReregisterAnnotation__RegisteredWithNewIdRemovesAnnotation", func(t *testing.T) {
+		astraConnector := newEventsAstraConnector()
+		astraConnector.Annotations = map[string]string{v1.ReregisterAnnotation: "true", "keep": "me"}
+		astraConnector.Status.NatsSyncClient.AstraClusterId = "123"
+		r, fakeClient := newController(astraConnector)
+
+		assert.NoError(t, r.removeReregisterAnnotation(context.Background(), astraConnector))
+
+		updated := &v1.AstraConnector{}
+		assert.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(astraConnector), updated))
+		assert.Equal(t, map[string]string{"keep": "me"}, updated.Annotations)
+	})
+
+	t.Run("RemoveReregisterAnnotation__NotYetRegisteredKeepsAnnotation", func(t *testing.T) {
+		astraConnector := newEventsAstraConnector()
+		astraConnector.Annotations = map[string]string{v1.ReregisterAnnotation: "true"}
+		astraConnector.Status.NatsSyncClient.AstraClusterId = "old"
+		r, fakeClient := newController(astraConnector)
+
+		assert.NoError(t, r.removeReregisterAnnotation(context.Background(), astraConnector))
+
+		updated := &v1.AstraConnector{}
+		assert.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(astraConnector), updated))
+		assert.True(t, updated.IsReregisterRequested())
+	})
+}
+
+func TestGetRegisteredClusterId(t *testing.T) {
+	astraConnector := &v1.AstraConnector{}
+	assert.Equal(t, "", getRegisteredClusterId(astraConnector))
+
+	astraConnector.Spec.Astra.ClusterId = "spec-cluster-id"
+	assert.Equal(t, "spec-cluster-id", getRegisteredClusterId(astraConnector))
+
+	// The spec was changed to a new cluster, the registered one is unregistered
+	astraConnector.Status.NatsSyncClient.AstraClusterId = "registered-cluster-id"
+	assert.Equal(t, "registered-cluster-id", getRegisteredClusterId(astraConnector))
+}
+
+func TestIsFirstTimeManaged(t *testing.T) {
+	t.Run("IsFirstTimeManaged__New", func(t *testing.T) {
+		assert.True(t, isFirstTimeManaged(&v1.AstraConnector{}))
+	})
+
+	t.Run("IsFirstTimeManaged__WaitingForRegistration", func(t *testing.T) {
+		astraConnector := &v1.AstraConnector{}
+		astraConnector.SetCondition(v1.ConditionClusterManaged, metav1.ConditionFalse, v1.ReasonClusterUnmanaged, "waiting")
+		assert.True(t, isFirstTimeManaged(astraConnector))
+	})
+
+	t.Run("IsFirstTimeManaged__ASUPFailedRequeued", func(t *testing.T) {
+		// The cluster is managed, the cluster ID is only recorded once the ASUP CR was created
+		astraConnector := &v1.AstraConnector{}
+		astraConnector.SetCondition(v1.ConditionClusterManaged, metav1.ConditionTrue, v1.ReasonClusterManaged, "managed")
+		astraConnector.SetCondition(v1.ConditionASUPConfigured, metav1.ConditionFalse, v1.ReasonASUPCreateFailed, "failed")
+		assert.False(t, isFirstTimeManaged(astraConnector))
+	})
+
+	t.Run("IsFirstTimeManaged__RegisteredBeforeConditions", func(t *testing.T) {
+		astraConnector := &v1.AstraConnector{}
+		astraConnector.Status.NatsSyncClient.AstraClusterId = "cluster-id"
+		assert.False(t, isFirstTimeManaged(astraConnector))
+	})
+
+	t.Run("IsFirstTimeManaged__UnmanagedInBetween", func(t *testing.T) {
+		astraConnector := &v1.AstraConnector{}
+		astraConnector.Status.NatsSyncClient.AstraClusterId = "cluster-id"
+		astraConnector.SetCondition(v1.ConditionClusterManaged, metav1.ConditionFalse, v1.ReasonClusterUnmanaged, "unmanaged")
+		assert.False(t, isFirstTimeManaged(astraConnector))
+	})
+}
+
+func TestGetEffectiveImages(t *testing.T) {
+	astraConnector := newEventsAstraConnector()
+	astraConnector.Spec.ImageRegistry.Name = "registry.example.com/astra"
+	astraConnector.Spec.Neptune.Image = "2.0.0"
+
+	// Both components are deployed by default
+	assert.Equal(t, &v1.ImagesStatus{
+		Connector: "registry.example.com/astra/astra-connector:" + common.ConnectorImageTag,
+		Neptune:   "registry.example.com/astra/controller:2.0.0",
+	}, getEffectiveImages(astraConnector))
+}
+
+func TestOwnerLabelsToRequests(t *testing.T) {
+	t.Run("OwnerLabelsToRequests__LabeledObjectMapsToOwner", func(t *testing.T) {
+		clusterRole := &rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "astraconnect",
+				Labels: map[string]string{
+					common.OwnerNameLabel:      "astra-connector",
+					common.OwnerNamespaceLabel: "astra-connector-ns",
+				},
+			},
+		}
+
+		requests := ownerLabelsToRequests(context.Background(), clusterRole)
+		assert.Equal(t, 1, len(requests))
+		assert.Equal(t, types.NamespacedName{Name: "astra-connector", Namespace: "astra-connector-ns"}, requests[0].NamespacedName)
+	})
+
+	t.Run("OwnerLabelsToRequests__UnlabeledObjectIsIgnored", func(t *testing.T) {
+		clusterRole := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "other"}}
+		assert.Empty(t, ownerLabelsToRequests(context.Background(), clusterRole))
+	})
+}
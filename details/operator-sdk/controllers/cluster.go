@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package controllers
+
+import (
+	"github.com/go-logr/logr"
+
+	"github.com/NetApp-Polaris/astra-connector-operator/details/k8s"
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+)
+
+// detectCluster records the flavor and versions of the cluster in the status of the AstraConnector, the cluster
+// is only detected again for a new generation of the AstraConnector or once the cluster was upgraded.
+// The kubernetes version is compared on every reconcile, it is served from the shared discovery cache.
+func detectCluster(astraConnector *v1.AstraConnector, k8sUtil k8s.K8sUtilInterface,
+	clusterTypeChecker k8s.ClusterTypeCheckerInterface, log logr.Logger) {
+	version, err := k8sUtil.VersionGet()
+	if err != nil {
+		log.Error(err, "Failed to get the kubernetes version, the cluster is detected on the next reconcile")
+		return
+	}
+
+	if astraConnector.Status.Cluster != nil && astraConnector.Status.Cluster.ObservedGeneration == astraConnector.Generation &&
+		astraConnector.Status.Cluster.KubernetesVersion == version {
+		return
+	}
+
+	// A flavor that was not detected is not recorded, OpenShift would get the pods of plain kubernetes
+	flavor, err := clusterTypeChecker.DetermineClusterType()
+	if err != nil {
+		log.Error(err, "Failed to determine the cluster flavor, the cluster is detected on the next reconcile")
+		return
+	}
+
+	cluster := &v1.ClusterStatus{
+		Flavor:             flavor,
+		KubernetesVersion:  version,
+		ObservedGeneration: astraConnector.Generation,
+	}
+	if k8s.IsOpenShiftFlavor(cluster.Flavor) {
+		openshiftVersion, err := clusterTypeChecker.GetOpenshiftVersion()
+		if err != nil {
+			log.Error(err, "Failed to get the OpenShift version")
+		}
+		cluster.OpenShiftVersion = openshiftVersion
+	}
+
+	log.Info("Detected cluster", "flavor", cluster.Flavor, "kubernetesVersion", cluster.KubernetesVersion,
+		"openShiftVersion", cluster.OpenShiftVersion)
+	astraConnector.Status.Cluster = cluster
+}
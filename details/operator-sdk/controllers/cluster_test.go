@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package controllers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/NetApp-Polaris/astra-connector-operator/details/k8s"
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+	"github.com/NetApp-Polaris/astra-connector-operator/mocks"
+	testutil "github.com/NetApp-Polaris/astra-connector-operator/test/test-util"
+)
+
+func TestDetectCluster(t *testing.T) {
+	newAstraConnector := func(cluster *v1.ClusterStatus) *v1.AstraConnector {
+		return &v1.AstraConnector{
+			ObjectMeta: metav1.ObjectMeta{Name: "astra-connector", Namespace: "astra-connector", Generation: 2},
+			Status:     v1.AstraConnectorStatus{Cluster: cluster},
+		}
+	}
+
+	t.Run("detectCluster__Kubernetes", func(t *testing.T) {
+		k8sUtil := mocks.NewK8sUtilInterface(t)
+		clusterTypeChecker := mocks.NewClusterTypeCheckerInterface(t)
+		k8sUtil.On("VersionGet").Return("v1.28.5-eks-5e0fdde", nil)
+		clusterTypeChecker.On("DetermineClusterType").Return(k8s.FlavorEKS, nil)
+		astraConnector := newAstraConnector(nil)
+
+		detectCluster(astraConnector, k8sUtil, clusterTypeChecker, testutil.CreateLoggerForTesting(t))
+		assert.Equal(t, &v1.ClusterStatus{Flavor: k8s.FlavorEKS, KubernetesVersion: "v1.28.5-eks-5e0fdde", ObservedGeneration: 2},
+			astraConnector.Status.Cluster)
+	})
+
+	t.Run("detectCluster__OpenShift", func(t *testing.T) {
+		k8sUtil := mocks.NewK8sUtilInterface(t)
+		clusterTypeChecker := mocks.NewClusterTypeCheckerInterface(t)
+		k8sUtil.On("VersionGet").Return("v1.27.8+b5c2b6e", nil)
+		clusterTypeChecker.On("DetermineClusterType").Return(k8s.FlavorROSA, nil)
+		clusterTypeChecker.On("GetOpenshiftVersion").Return("4.14.8", nil)
+		astraConnector := newAstraConnector(nil)
+
+		detectCluster(astraConnector, k8sUtil, clusterTypeChecker, testutil.CreateLoggerForTesting(t))
+		assert.Equal(t, &v1.ClusterStatus{Flavor: k8s.FlavorROSA, KubernetesVersion: "v1.27.8+b5c2b6e",
+			OpenShiftVersion: "4.14.8", ObservedGeneration: 2}, astraConnector.Status.Cluster)
+	})
+
+	t.Run("detectCluster__SameGeneration", func(t *testing.T) {
+		k8sUtil := mocks.NewK8sUtilInterface(t)
+		k8sUtil.On("VersionGet").Return("v1.28.1", nil)
+		cluster := &v1.ClusterStatus{Flavor: k8s.FlavorKubernetes, KubernetesVersion: "v1.28.1", ObservedGeneration: 2}
+		astraConnector := newAstraConnector(cluster)
+
+		detectCluster(astraConnector, k8sUtil, mocks.NewClusterTypeCheckerInterface(t), testutil.CreateLoggerForTesting(t))
+		assert.Same(t, cluster, astraConnector.Status.Cluster)
+	})
+
+	t.Run("detectCluster__SameGenerationUpgradedCluster", func(t *testing.T) {
+		k8sUtil := mocks.NewK8sUtilInterface(t)
+		clusterTypeChecker := mocks.NewClusterTypeCheckerInterface(t)
+		k8sUtil.On("VersionGet").Return("v1.29.2", nil)
+		clusterTypeChecker.On("DetermineClusterType").Return(k8s.FlavorKubernetes, nil)
+		astraConnector := newAstraConnector(&v1.ClusterStatus{Flavor: k8s.FlavorKubernetes, KubernetesVersion: "v1.28.1", ObservedGeneration: 2})
+
+		detectCluster(astraConnector, k8sUtil, clusterTypeChecker, testutil.CreateLoggerForTesting(t))
+		assert.Equal(t, &v1.ClusterStatus{Flavor: k8s.FlavorKubernetes, KubernetesVersion: "v1.29.2", ObservedGeneration: 2},
+			astraConnector.Status.Cluster)
+	})
+
+	t.Run("detectCluster__NewGeneration", func(t *testing.T) {
+		k8sUtil := mocks.NewK8sUtilInterface(t)
+		clusterTypeChecker := mocks.NewClusterTypeCheckerInterface(t)
+		k8sUtil.On("VersionGet").Return("v1.29.0", nil)
+		clusterTypeChecker.On("DetermineClusterType").Return(k8s.FlavorKubernetes, nil)
+		astraConnector := newAstraConnector(&v1.ClusterStatus{Flavor: k8s.FlavorKubernetes, KubernetesVersion: "v1.28.1", ObservedGeneration: 1})
+
+		detectCluster(astraConnector, k8sUtil, clusterTypeChecker, testutil.CreateLoggerForTesting(t))
+		assert.Equal(t, "v1.29.0", astraConnector.Status.Cluster.KubernetesVersion)
+		assert.Equal(t, int64(2), astraConnector.Status.Cluster.ObservedGeneration)
+	})
+
+	t.Run("detectCluster__VersionGetFailed", func(t *testing.T) {
+		k8sUtil := mocks.NewK8sUtilInterface(t)
+		k8sUtil.On("VersionGet").Return("", errors.New("connection refused"))
+		astraConnector := newAstraConnector(nil)
+
+		detectCluster(astraConnector, k8sUtil, mocks.NewClusterTypeCheckerInterface(t), testutil.CreateLoggerForTesting(t))
+		assert.Nil(t, astraConnector.Status.Cluster)
+	})
+
+	t.Run("detectCluster__FlavorNotDetermined", func(t *testing.T) {
+		k8sUtil := mocks.NewK8sUtilInterface(t)
+		clusterTypeChecker := mocks.NewClusterTypeCheckerInterface(t)
+		k8sUtil.On("VersionGet").Return("v1.27.8+b5c2b6e", nil)
+		clusterTypeChecker.On("DetermineClusterType").Return("", errors.New("unable to get the API groups"))
+		astraConnector := newAstraConnector(nil)
+
+		// The flavor is not recorded as kubernetes, so the next reconcile detects it again
+		detectCluster(astraConnector, k8sUtil, clusterTypeChecker, testutil.CreateLoggerForTesting(t))
+		assert.Nil(t, astraConnector.Status.Cluster)
+	})
+
+	t.Run("detectCluster__VersionGetFailedKeepsDetected", func(t *testing.T) {
+		k8sUtil := mocks.NewK8sUtilInterface(t)
+		k8sUtil.On("VersionGet").Return("", errors.New("connection refused"))
+		cluster := &v1.ClusterStatus{Flavor: k8s.FlavorKubernetes, KubernetesVersion: "v1.28.1", ObservedGeneration: 2}
+		astraConnector := newAstraConnector(cluster)
+
+		detectCluster(astraConnector, k8sUtil, mocks.NewClusterTypeCheckerInterface(t), testutil.CreateLoggerForTesting(t))
+		assert.Same(t, cluster, astraConnector.Status.Cluster)
+	})
+}
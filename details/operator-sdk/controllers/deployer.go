@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"github.com/NetApp-Polaris/astra-connector-operator/app/conf"
+	"github.com/NetApp-Polaris/astra-connector-operator/app/metrics"
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"reflect"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"time"
@@ -29,12 +32,14 @@ type createResourceParams struct {
 	clusterScope  bool
 }
 
-const ()
+// resourceReadyRequeueInterval is how often a reconcile checks again on Deployments and StatefulSets that are rolling out
+const resourceReadyRequeueInterval = 10 * time.Second
 
 // ResourcesToDeploy This is a list and order matters since things will be created in the order specified
 var resources = []createResourceParams{
 	{createMessage: CreateConfigMap, errorMessage: ErrorCreateConfigMaps, getResource: model.Deployer.GetConfigMapObjects, clusterScope: false},
 	{createMessage: CreateRole, errorMessage: ErrorCreateRoles, getResource: model.Deployer.GetRoleObjects, clusterScope: false},
+	{createMessage: CreateSecurityContextConstraints, errorMessage: ErrorCreateSCCs, getResource: model.Deployer.GetSecurityContextConstraintsObjects, clusterScope: true},
 	{createMessage: CreateClusterRole, errorMessage: ErrorCreateClusterRoles, getResource: model.Deployer.GetClusterRoleObjects, clusterScope: true},
 	{createMessage: CreateRoleBinding, errorMessage: ErrorCreateRoleBindings, getResource: model.Deployer.GetRoleBindingObjects, clusterScope: false},
 	{createMessage: CreateClusterRoleBinding, errorMessage: ErrorCreateClusterRoleBindings, getResource: model.Deployer.GetClusterRoleBindingObjects, clusterScope: true},
@@ -44,15 +49,19 @@ var resources = []createResourceParams{
 	{createMessage: CreateDeployment, errorMessage: ErrorCreateDeployments, getResource: model.Deployer.GetDeploymentObjects, clusterScope: false},
 }
 
-func (r *AstraConnectorController) deployResources(ctx context.Context, deployer model.Deployer, astraConnector *installer.AstraConnector, natsSyncClientStatus *installer.NatsSyncClientStatus) error {
+// deployResources applies every resource of the deployer without waiting for them to become ready.
+// The Deployments and StatefulSets that are not ready yet are returned so the caller can requeue and check again.
+func (r *AstraConnectorController) deployResources(ctx context.Context, deployer model.Deployer, astraConnector *installer.AstraConnector, natsSyncClientStatus *installer.NatsSyncClientStatus) ([]string, error) {
 	log := ctrllog.FromContext(ctx)
 	k8sUtil := k8s.NewK8sUtil(r.Client, r.Clientset, log)
 
+	serverSideApply := model.UsesServerSideApply(deployer)
+	var notReady []string
 	for _, funcList := range resources {
 
 		resourceList, mutateFunc, err := funcList.getResource(deployer, astraConnector, ctx)
 		if err != nil {
-			return errors.Wrapf(err, "Unable to get resource")
+			return nil, errors.Wrapf(err, "Unable to get resource")
 		}
 		if resourceList == nil {
 			continue
@@ -60,27 +69,40 @@ func (r *AstraConnectorController) deployResources(ctx context.Context, deployer
 
 		for _, kubeObject := range resourceList {
 			key := client.ObjectKeyFromObject(kubeObject)
+			kind := getObjectKind(kubeObject)
 			statusMsg := fmt.Sprintf(funcList.createMessage, key.Namespace, key.Name)
 			log.Info(statusMsg)
 			natsSyncClientStatus.Status = statusMsg
 			_ = r.updateAstraConnectorStatus(ctx, astraConnector, *natsSyncClientStatus)
 
-			result, err := k8sUtil.CreateOrUpdateResource(ctx, kubeObject, astraConnector, mutateFunc)
-			if err != nil {
-				return r.formatError(ctx, astraConnector, log, funcList.errorMessage, key.Namespace, key.Name, err, natsSyncClientStatus)
+			var result string
+			if serverSideApply {
+				result, err = k8sUtil.ApplyResource(ctx, kubeObject, astraConnector)
 			} else {
-				waitCtx, cancel := context.WithCancel(ctx)
-				defer cancel()
-				err = r.waitForResourceReady(waitCtx, kubeObject, astraConnector)
-				if err != nil {
-					return r.formatError(ctx, astraConnector, log, funcList.errorMessage, key.Namespace, key.Name, err, natsSyncClientStatus)
-				}
-				log.Info(fmt.Sprintf("Successfully %s resources", result))
+				result, err = k8sUtil.CreateOrUpdateResource(ctx, kubeObject, astraConnector, mutateFunc)
+			}
+			if err != nil {
+				metrics.DeployerApplyErrors.WithLabelValues(getDeployerName(deployer), kind).Inc()
+				r.recordWarning(astraConnector, EventReasonResourceFailed, "%s: %v", fmt.Sprintf(funcList.errorMessage, key.Namespace, key.Name), err)
+				return nil, r.formatError(ctx, astraConnector, log, funcList.errorMessage, key.Namespace, key.Name, err, natsSyncClientStatus)
+			}
+			log.Info(fmt.Sprintf("Successfully %s resources", result))
+			switch controllerutil.OperationResult(result) {
+			case controllerutil.OperationResultCreated:
+				r.recordEvent(astraConnector, EventReasonResourceCreated, "Created %s %s", kind, objectName(key))
+			case controllerutil.OperationResultUpdated:
+				r.recordEvent(astraConnector, EventReasonResourceUpdated, "Updated %s %s", kind, objectName(key))
+			}
+
+			// Both leave the object as returned by the API server, so its status can be checked directly
+			if !isResourceReady(kubeObject) {
+				log.Info("Resource is not ready yet", "namespace", key.Namespace, "name", key.Name)
+				notReady = append(notReady, fmt.Sprintf("%s %s/%s", kind, key.Namespace, key.Name))
 			}
 		}
 
 	}
-	return nil
+	return notReady, nil
 }
 
 func (r *AstraConnectorController) deleteClusterScopedResources(ctx context.Context, deployer model.Deployer, astraConnector *installer.AstraConnector) {
@@ -100,60 +122,80 @@ func (r *AstraConnectorController) deleteClusterScopedResources(ctx context.Cont
 
 		for _, kubeObject := range resourceList {
 			key := client.ObjectKeyFromObject(kubeObject)
-			objectKind := reflect.TypeOf(kubeObject).String()
+			objectKind := getObjectKind(kubeObject)
 
 			log.WithValues("name", key.Name, "kind", objectKind).Info("Deleting resource")
 			err := k8sUtil.DeleteResource(ctx, kubeObject)
 			if err != nil {
 				log.WithValues("name", key.Name, "kind", objectKind).Error(err, "error deleting resource")
+				r.recordWarning(astraConnector, EventReasonClusterScopedResourceFailed, "Failed to delete %s %s: %v",
+					objectKind, key.Name, err)
 				return
 			}
 			log.WithValues("name", key.Name, "kind", objectKind).Info("Deleted resource")
+			r.recordEvent(astraConnector, EventReasonClusterScopedResourceDeleted, "Deleted %s %s", objectKind, key.Name)
 		}
 	}
 }
 
-func (r *AstraConnectorController) waitForResourceReady(ctx context.Context, kubeObject client.Object, astraConnector *installer.AstraConnector) error {
-	log := ctrllog.FromContext(ctx)
-	timeout := time.After(conf.Config.WaitDurationForResource()) // default is 2 mins
-	ticker := time.NewTicker(3 * time.Second)
-	originalSpec := astraConnector.Spec
-
-	for {
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("reconcile cancelled")
-		case <-timeout:
-			return fmt.Errorf("timed out waiting for resource %s/%s to be ready", kubeObject.GetNamespace(), kubeObject.GetName())
-		case <-ticker.C:
-			// First lets make sure controller isn't modified, this check allow us
-			// to respond faster when there is a cr spec change or deletion
-			if r.isControllerModified(ctx, astraConnector, originalSpec) {
-				return fmt.Errorf("controller updated, requeue to handle changes")
-			}
+// getObjectKind Returns the kind of the object, e.g. Deployment, unstructured objects carry theirs in the GVK
+func getObjectKind(obj client.Object) string {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u.GetKind()
+	}
+	return reflect.TypeOf(obj).Elem().Name()
+}
 
-			err := r.Client.Get(ctx, client.ObjectKeyFromObject(kubeObject), kubeObject)
-			if err != nil {
-				log.Error(err, "Error getting resource", "namespace", kubeObject.GetNamespace(), "name", kubeObject.GetName())
-				continue
-			}
+// getDeployerName Returns the type name of the deployer, e.g. AstraConnectDeployer
+func getDeployerName(deployer model.Deployer) string {
+	return reflect.Indirect(reflect.ValueOf(deployer)).Type().Name()
+}
 
-			isReady := false
-			switch obj := kubeObject.(type) {
-			case *appsv1.Deployment:
-				isReady = obj.Status.ReadyReplicas == obj.Status.Replicas
-			case *appsv1.StatefulSet:
-				isReady = obj.Status.ReadyReplicas == obj.Status.Replicas && obj.Status.CurrentReplicas == obj.Status.Replicas
-			default:
-				isReady = true
-			}
+// isResourceReady Returns true once a Deployment or StatefulSet has rolled out all replicas of its current spec,
+// other kinds of resources are ready as soon as they are applied
+func isResourceReady(kubeObject client.Object) bool {
+	switch obj := kubeObject.(type) {
+	case *appsv1.Deployment:
+		replicas := replicasOrDefault(obj.Spec.Replicas)
+		return obj.Status.ObservedGeneration >= obj.Generation &&
+			obj.Status.UpdatedReplicas == replicas &&
+			obj.Status.ReadyReplicas == replicas &&
+			obj.Status.Replicas == replicas
+	case *appsv1.StatefulSet:
+		replicas := replicasOrDefault(obj.Spec.Replicas)
+		return obj.Status.ObservedGeneration >= obj.Generation &&
+			obj.Status.UpdatedReplicas == replicas &&
+			obj.Status.ReadyReplicas == replicas
+	default:
+		return true
+	}
+}
 
-			if isReady {
-				log.Info("Resource is ready", "namespace", kubeObject.GetNamespace(), "name", kubeObject.GetName())
-				return nil
-			}
-		}
+// replicasOrDefault Returns the replica count of a workload, which defaults to 1 when not set
+func replicasOrDefault(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
 	}
+	return *replicas
+}
+
+// setDeployInProgressCondition Reports a component that is still rolling out on its condition and on Ready.
+// Once the component has not been deployed for longer than WaitDurationForResource the rollout is reported as failed,
+// the reconcile keeps checking on it either way.
+func setDeployInProgressCondition(astraConnector *installer.AstraConnector, conditionType string, natsSyncClientStatus *installer.NatsSyncClientStatus) {
+	reason := installer.ReasonDeployInProgress
+	message := natsSyncClientStatus.Status
+
+	condition := astraConnector.GetCondition(conditionType)
+	if condition != nil && condition.Status == metav1.ConditionFalse &&
+		time.Since(condition.LastTransitionTime.Time) > conf.Config.WaitDurationForResource() {
+		reason = installer.ReasonDeployFailed
+		message = fmt.Sprintf("%s; %s", ErrorResourcesNotReady, message)
+		natsSyncClientStatus.Status = message
+	}
+
+	astraConnector.SetCondition(conditionType, metav1.ConditionFalse, reason, message)
+	astraConnector.SetCondition(installer.ConditionReady, metav1.ConditionFalse, reason, message)
 }
 
 func (r *AstraConnectorController) formatError(ctx context.Context, astraConnector *installer.AstraConnector,
@@ -165,27 +207,3 @@ func (r *AstraConnectorController) formatError(ctx context.Context, astraConnect
 	log.Error(err, statusMsg)
 	return errors.Wrapf(err, statusMsg)
 }
-
-func (r *AstraConnectorController) isControllerModified(ctx context.Context,
-	astraConnector *installer.AstraConnector, originalSpec installer.AstraConnectorSpec) bool {
-	log := ctrllog.FromContext(ctx)
-	// Fetch the AstraConnector instance
-	controllerKey := client.ObjectKeyFromObject(astraConnector)
-	updatedAstraConnector := &installer.AstraConnector{}
-	err := r.Get(ctx, controllerKey, updatedAstraConnector)
-	if err != nil {
-		log.Info("AstraConnector resource not found. Ignoring since object must be deleted")
-		return true
-	}
-
-	if updatedAstraConnector.GetDeletionTimestamp() != nil {
-		log.Info("AstraConnector marked for deletion, reconciler requeue")
-		return true
-	}
-
-	if !reflect.DeepEqual(updatedAstraConnector.Spec, originalSpec) {
-		log.Info("AstraConnector spec change, reconciler requeue")
-		return true
-	}
-	return false
-}
@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/NetApp-Polaris/astra-connector-operator/app/deployer/connector"
+	"github.com/NetApp-Polaris/astra-connector-operator/app/deployer/neptune"
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+)
+
+func TestIsResourceReady(t *testing.T) {
+	testCases := []struct {
+		name     string
+		object   client.Object
+		expected bool
+	}{
+		{
+			name:     "configmap is ready once applied",
+			object:   &corev1.ConfigMap{},
+			expected: true,
+		},
+		{
+			name: "new deployment is not ready",
+			object: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 1},
+				Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(1)},
+			},
+			expected: false,
+		},
+		{
+			name: "rolled out deployment is ready",
+			object: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(1)},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1},
+			},
+			expected: true,
+		},
+		{
+			name: "deployment with a spec change not yet observed is not ready",
+			object: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 3},
+				Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(1)},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1},
+			},
+			expected: false,
+		},
+		{
+			name: "deployment still replacing old pods is not ready",
+			object: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 1, ReadyReplicas: 2},
+			},
+			expected: false,
+		},
+		{
+			name: "rolled out statefulset is ready",
+			object: &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 1},
+				Spec:       appsv1.StatefulSetSpec{Replicas: pointer.Int32(2)},
+				Status:     appsv1.StatefulSetStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 2},
+			},
+			expected: true,
+		},
+		{
+			name: "statefulset with unready replicas is not ready",
+			object: &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 1},
+				Spec:       appsv1.StatefulSetSpec{Replicas: pointer.Int32(2)},
+				Status:     appsv1.StatefulSetStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 1},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isResourceReady(tc.object))
+		})
+	}
+}
+
+func TestSetDeployInProgressCondition(t *testing.T) {
+	t.Run("SetDeployInProgressCondition__NewRolloutIsInProgress", func(t *testing.T) {
+		astraConnector := &v1.AstraConnector{}
+		natsSyncClientStatus := &v1.NatsSyncClientStatus{Status: "Waiting for Deployment ns/astraconnect to be ready"}
+
+		setDeployInProgressCondition(astraConnector, v1.ConditionConnectorDeployed, natsSyncClientStatus)
+
+		condition := astraConnector.GetCondition(v1.ConditionConnectorDeployed)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, v1.ReasonDeployInProgress, condition.Reason)
+		assert.Equal(t, natsSyncClientStatus.Status, condition.Message)
+		assert.Equal(t, v1.ReasonDeployInProgress, astraConnector.GetCondition(v1.ConditionReady).Reason)
+	})
+
+	t.Run("SetDeployInProgressCondition__LongRolloutIsFailed", func(t *testing.T) {
+		astraConnector := &v1.AstraConnector{}
+		astraConnector.Status.Conditions = []metav1.Condition{{
+			Type:               v1.ConditionConnectorDeployed,
+			Status:             metav1.ConditionFalse,
+			Reason:             v1.ReasonDeployInProgress,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+		}}
+		natsSyncClientStatus := &v1.NatsSyncClientStatus{Status: "Waiting for Deployment ns/astraconnect to be ready"}
+
+		setDeployInProgressCondition(astraConnector, v1.ConditionConnectorDeployed, natsSyncClientStatus)
+
+		condition := astraConnector.GetCondition(v1.ConditionConnectorDeployed)
+		assert.Equal(t, v1.ReasonDeployFailed, condition.Reason)
+		assert.Contains(t, condition.Message, ErrorResourcesNotReady)
+		assert.Contains(t, natsSyncClientStatus.Status, ErrorResourcesNotReady)
+	})
+}
+
+func TestGetDeployerName(t *testing.T) {
+	assert.Equal(t, "AstraConnectDeployer", getDeployerName(connector.NewAstraConnectorDeployer()))
+	assert.Equal(t, "NeptuneClientDeployerV2", getDeployerName(neptune.NewNeptuneClientDeployerV2()))
+	assert.Equal(t, "NeptuneClientDeployerV2", getDeployerName(neptune.NeptuneClientDeployerV2{}))
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package controllers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+)
+
+// Reasons of the events recorded on the AstraConnector, so `kubectl describe astraconnector` shows its history
+const (
+	EventReasonResourceCreated              = "ResourceCreated"
+	EventReasonResourceUpdated              = "ResourceUpdated"
+	EventReasonResourceFailed               = "ResourceFailed"
+	EventReasonPrecheckFailed               = "PrecheckFailed"
+	EventReasonPrecheckWarning              = "PrecheckWarning"
+	EventReasonClusterManaged               = "ClusterManaged"
+	EventReasonClusterUnmanaged             = "ClusterUnmanaged"
+	EventReasonASUPConfigured               = "ASUPConfigured"
+	EventReasonASUPFailed                   = "ASUPFailed"
+	EventReasonDeleting                     = "Deleting"
+	EventReasonUnregistered                 = "Unregistered"
+	EventReasonUnregisterFailed             = "UnregisterFailed"
+	EventReasonClusterScopedResourceDeleted = "ClusterScopedResourceDeleted"
+	EventReasonClusterScopedResourceFailed  = "ClusterScopedResourceDeleteFailed"
+	EventReasonDeleted                      = "Deleted"
+	EventReasonPaused                       = "Paused"
+	EventReasonResumed                      = "Resumed"
+)
+
+// recordEvent Records a Normal event on the AstraConnector, nothing is recorded when the controller has no Recorder
+func (r *AstraConnectorController) recordEvent(astraConnector *v1.AstraConnector, reason, messageFmt string, args ...interface{}) {
+	if r.Recorder != nil {
+		r.Recorder.Eventf(astraConnector, corev1.EventTypeNormal, reason, messageFmt, args...)
+	}
+}
+
+// recordWarning Records a Warning event on the AstraConnector, nothing is recorded when the controller has no Recorder
+func (r *AstraConnectorController) recordWarning(astraConnector *v1.AstraConnector, reason, messageFmt string, args ...interface{}) {
+	if r.Recorder != nil {
+		r.Recorder.Eventf(astraConnector, corev1.EventTypeWarning, reason, messageFmt, args...)
+	}
+}
+
+// objectName Returns namespace/name of a namespaced object and only the name of a cluster scoped one
+func objectName(key client.ObjectKey) string {
+	if key.Namespace == "" {
+		return key.Name
+	}
+	return key.String()
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/NetApp-Polaris/astra-connector-operator/app/deployer/connector"
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+	testutil "github.com/NetApp-Polaris/astra-connector-operator/test/test-util"
+)
+
+// drainEvents Returns the events recorded so far
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func newEventsAstraConnector() *v1.AstraConnector {
+	return &v1.AstraConnector{
+		ObjectMeta: metav1.ObjectMeta{Name: "astra-connector", Namespace: "astra-connector", UID: "uid"},
+		Spec: v1.AstraConnectorSpec{
+			Astra:        v1.Astra{ClusterId: "123"},
+			AstraConnect: v1.AstraConnect{Replicas: 1},
+		},
+	}
+}
+
+func TestRecordEvent(t *testing.T) {
+	t.Run("RecordEvent__NoRecorderIsIgnored", func(t *testing.T) {
+		r := &AstraConnectorController{}
+		r.recordEvent(newEventsAstraConnector(), EventReasonDeleting, DeleteInProgress)
+		r.recordWarning(newEventsAstraConnector(), EventReasonPrecheckFailed, "failed")
+	})
+
+	t.Run("RecordEvent__EventTypes", func(t *testing.T) {
+		recorder := record.NewFakeRecorder(10)
+		r := &AstraConnectorController{Recorder: recorder}
+		r.recordEvent(newEventsAstraConnector(), EventReasonClusterManaged, "Cluster %s is managed by Astra", "123")
+		r.recordWarning(newEventsAstraConnector(), EventReasonPrecheckFailed, "%s", "Pre-check errors: unsupported version")
+
+		assert.Equal(t, []string{
+			"Normal ClusterManaged Cluster 123 is managed by Astra",
+			"Warning PrecheckFailed Pre-check errors: unsupported version",
+		}, drainEvents(recorder))
+	})
+}
+
+func TestDeployResourcesEvents(t *testing.T) {
+	astraConnector := newEventsAstraConnector()
+	recorder := record.NewFakeRecorder(100)
+	r := &AstraConnectorController{Client: testutil.CreateFakeClient(astraConnector), Recorder: recorder}
+	ctx := context.Background()
+
+	_, err := r.deployResources(ctx, connector.NewAstraConnectorDeployer(), astraConnector, &v1.NatsSyncClientStatus{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"Normal ResourceCreated Created ConfigMap astra-connector/astraconnect",
+		"Normal ResourceCreated Created Role astra-connector/astraconnect",
+		"Normal ResourceCreated Created ClusterRole astraconnect",
+		"Normal ResourceCreated Created RoleBinding astra-connector/astraconnect",
+		"Normal ResourceCreated Created ClusterRoleBinding astraconnect",
+		"Normal ResourceCreated Created ServiceAccount astra-connector/astraconnect",
+		"Normal ResourceCreated Created Deployment astra-connector/astraconnect",
+	}, drainEvents(recorder))
+
+	// Nothing changed, so there is nothing to report
+	_, err = r.deployResources(ctx, connector.NewAstraConnectorDeployer(), astraConnector, &v1.NatsSyncClientStatus{})
+	assert.NoError(t, err)
+	assert.Empty(t, drainEvents(recorder))
+
+	astraConnector.Spec.AstraConnect.Replicas = 2
+	_, err = r.deployResources(ctx, connector.NewAstraConnectorDeployer(), astraConnector, &v1.NatsSyncClientStatus{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Normal ResourceUpdated Updated Deployment astra-connector/astraconnect"}, drainEvents(recorder))
+
+	r.deleteClusterScopedResources(ctx, connector.NewAstraConnectorDeployer(), astraConnector)
+	assert.Equal(t, []string{
+		"Normal ClusterScopedResourceDeleted Deleted ClusterRole astraconnect",
+		"Normal ClusterScopedResourceDeleted Deleted ClusterRoleBinding astraconnect",
+	}, drainEvents(recorder))
+
+	r.deleteClusterScopedResources(ctx, connector.NewAstraConnectorDeployer(), astraConnector)
+	events := drainEvents(recorder)
+	assert.Equal(t, 1, len(events))
+	assert.Contains(t, events[0], "Warning ClusterScopedResourceDeleteFailed Failed to delete ClusterRole astraconnect")
+}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"github.com/NetApp-Polaris/astra-connector-operator/details/k8s"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"strings"
 	"time"
 
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/NetApp-Polaris/astra-connector-operator/app/conf"
@@ -22,14 +24,22 @@ func (r *AstraConnectorController) deployNatlessConnector(ctx context.Context,
 
 	// let's deploy Astra Connector without Nats
 	connectorDeployers := getDeployers()
+	var notReady []string
 	for _, deployer := range connectorDeployers {
-		err := r.deployResources(ctx, deployer, astraConnector, natsSyncClientStatus)
+		deployerNotReady, err := r.deployResources(ctx, deployer, astraConnector, natsSyncClientStatus)
 		if err != nil {
 			// Failed deploying we want status to reflect that for at least 30 seconds before it's requeued so
 			// anyone watching can be informed
 			log.V(3).Info("Requeue after 30 seconds, so that status reflects error")
 			return ctrl.Result{RequeueAfter: time.Minute * conf.Config.ErrorTimeout()}, err
 		}
+		notReady = append(notReady, deployerNotReady...)
+	}
+
+	if len(notReady) > 0 {
+		// Still rolling out, check again later instead of blocking the reconcile
+		natsSyncClientStatus.Status = fmt.Sprintf(WaitForResourcesReady, strings.Join(notReady, ", "))
+		return ctrl.Result{RequeueAfter: resourceReadyRequeueInterval}, nil
 	}
 
 	// No need to requeue due to success
@@ -44,6 +54,21 @@ func (r *AstraConnectorController) createASUPCR(ctx context.Context, astraConnec
 	log := ctrllog.FromContext(ctx)
 	k8sUtil := k8s.NewK8sUtil(r.Client, r.Clientset, log)
 
+	cr, mutateFn := getASUPCR(astraConnector, astraClusterID)
+	result, err := k8sUtil.CreateOrUpdateResource(ctx, cr, astraConnector, mutateFn)
+	if err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("Successfully %s AutoSupportBundleSchedule", result))
+	if controllerutil.OperationResult(result) != controllerutil.OperationResultNone {
+		r.recordEvent(astraConnector, EventReasonASUPConfigured, "AutoSupportBundleSchedule %s %s", cr.GetName(), result)
+	}
+	return nil
+}
+
+// getASUPCR Returns the AutoSupportBundleSchedule of the cluster and the MutateFn that keeps it in sync with the spec
+func getASUPCR(astraConnector *v1.AstraConnector, astraClusterID string) (*unstructured.Unstructured, controllerutil.MutateFn) {
 	cr := &unstructured.Unstructured{
 		Object: map[string]interface{}{
 			"apiVersion": "astra.netapp.io/v1",
@@ -62,13 +87,7 @@ func (r *AstraConnectorController) createASUPCR(ctx context.Context, astraConnec
 		cr.Object["spec"].(map[string]interface{})["enabled"] = astraConnector.Spec.AutoSupport.Enrolled
 		return nil
 	}
-	result, err := k8sUtil.CreateOrUpdateResource(ctx, cr, astraConnector, mutateFn)
-	if err != nil {
-		return err
-	}
-
-	log.Info(fmt.Sprintf("Successfully %s AutoSupportBundleSchedule", result))
-	return nil
+	return cr, mutateFn
 }
 
 func (r *AstraConnectorController) deleteConnectorClusterScopedResources(ctx context.Context, astraConnector *v1.AstraConnector) {
@@ -2,6 +2,8 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -19,13 +21,19 @@ func (r *AstraConnectorController) deployNeptune(ctx context.Context,
 
 	// Deploy Neptune
 	neptuneDeployer := neptune.NewNeptuneClientDeployerV2()
-	err := r.deployResources(ctx, neptuneDeployer, astraConnector, natsSyncClientStatus)
+	notReady, err := r.deployResources(ctx, neptuneDeployer, astraConnector, natsSyncClientStatus)
 	if err != nil {
 		// Failed deploying we want status to reflect that for at least 30 seconds before it's requeued so
 		// anyone watching can be informed
 		return ctrl.Result{RequeueAfter: time.Minute * conf.Config.ErrorTimeout()}, err
 	}
 
+	if len(notReady) > 0 {
+		// Still rolling out, check again later instead of blocking the reconcile
+		natsSyncClientStatus.Status = fmt.Sprintf(WaitForResourcesReady, strings.Join(notReady, ", "))
+		return ctrl.Result{RequeueAfter: resourceReadyRequeueInterval}, nil
+	}
+
 	// No need to requeue due to success
 	return ctrl.Result{}, nil
 }
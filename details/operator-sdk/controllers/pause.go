@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+)
+
+// pausedChanged triggers a reconcile when the paused annotation is set or removed, annotations do not change the generation
+var pausedChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return e.ObjectOld.GetAnnotations()[v1.PausedAnnotation] != e.ObjectNew.GetAnnotations()[v1.PausedAnnotation]
+	},
+}
+
+// reconcilePaused records that the AstraConnector is paused, nothing is applied or deleted until the annotation is removed
+func (r *AstraConnectorController) reconcilePaused(ctx context.Context, astraConnector *v1.AstraConnector,
+	natsSyncClientStatus *v1.NatsSyncClientStatus) (ctrl.Result, error) {
+	log := ctrllog.FromContext(ctx)
+	log.Info("AstraConnector is paused, skipping reconcile", "annotation", v1.PausedAnnotation)
+
+	if !astraConnector.IsConditionTrue(v1.ConditionPaused) {
+		r.recordEvent(astraConnector, EventReasonPaused, ReconcilePaused)
+	}
+	natsSyncClientStatus.Status = ReconcilePaused
+	astraConnector.SetCondition(v1.ConditionPaused, metav1.ConditionTrue, v1.ReasonPaused, ReconcilePaused)
+	_ = r.updateAstraConnectorStatus(ctx, astraConnector, *natsSyncClientStatus)
+
+	// Do not requeue, removing the annotation triggers the next reconcile
+	return ctrl.Result{}, nil
+}
+
+// resumeReconcile marks a paused AstraConnector as resumed. The rest of the reconcile then applies every resource
+// again, which reverts the changes made while it was paused.
+func (r *AstraConnectorController) resumeReconcile(ctx context.Context, astraConnector *v1.AstraConnector) {
+	if !astraConnector.IsConditionTrue(v1.ConditionPaused) {
+		return
+	}
+	ctrllog.FromContext(ctx).Info("AstraConnector is no longer paused, resuming reconcile")
+
+	r.recordEvent(astraConnector, EventReasonResumed, ReconcileResumed)
+	astraConnector.SetCondition(v1.ConditionPaused, metav1.ConditionFalse, v1.ReasonResumed, ReconcileResumed)
+	astraConnector.SetCondition(v1.ConditionReady, metav1.ConditionFalse, v1.ReasonReconciling, ReconcileResumed)
+	// The cluster may have been upgraded while paused, so it is detected again
+	if astraConnector.Status.Cluster != nil {
+		astraConnector.Status.Cluster.ObservedGeneration = 0
+	}
+}
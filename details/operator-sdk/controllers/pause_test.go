@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+)
+
+func TestReconcilePaused(t *testing.T) {
+	astraConnector := newEventsAstraConnector()
+	astraConnector.Annotations = map[string]string{v1.PausedAnnotation: "true"}
+	scheme := runtime.NewScheme()
+	assert.NoError(t, v1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(astraConnector).
+		WithStatusSubresource(astraConnector).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &AstraConnectorController{Client: fakeClient, Recorder: recorder}
+	ctx := context.Background()
+	request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(astraConnector)}
+
+	for i := 0; i < 2; i++ {
+		result, err := r.Reconcile(ctx, request)
+		assert.NoError(t, err)
+		assert.True(t, result.IsZero())
+	}
+
+	paused := &v1.AstraConnector{}
+	assert.NoError(t, fakeClient.Get(ctx, request.NamespacedName, paused))
+	// Nothing was applied, not even the finalizer
+	assert.Empty(t, paused.Finalizers)
+	assert.True(t, paused.IsConditionTrue(v1.ConditionPaused))
+	assert.Equal(t, ReconcilePaused, paused.Status.NatsSyncClient.Status)
+	// The pause is only reported once
+	assert.Equal(t, []string{"Normal Paused " + ReconcilePaused}, drainEvents(recorder))
+}
+
+func TestResumeReconcile(t *testing.T) {
+	t.Run("ResumeReconcile__NotPausedIsUnchanged", func(t *testing.T) {
+		recorder := record.NewFakeRecorder(10)
+		r := &AstraConnectorController{Recorder: recorder}
+		astraConnector := newEventsAstraConnector()
+
+		r.resumeReconcile(context.Background(), astraConnector)
+		assert.Nil(t, astraConnector.GetCondition(v1.ConditionPaused))
+		assert.Empty(t, drainEvents(recorder))
+	})
+
+	t.Run("ResumeReconcile__PausedIsResumed", func(t *testing.T) {
+		recorder := record.NewFakeRecorder(10)
+		r := &AstraConnectorController{Recorder: recorder}
+		astraConnector := newEventsAstraConnector()
+		astraConnector.Generation = 3
+		astraConnector.Status.Cluster = &v1.ClusterStatus{Flavor: "gke", ObservedGeneration: 3}
+		astraConnector.SetCondition(v1.ConditionPaused, metav1.ConditionTrue, v1.ReasonPaused, ReconcilePaused)
+		astraConnector.SetCondition(v1.ConditionReady, metav1.ConditionTrue, v1.ReasonReconcileSucceeded, DeployedComponents)
+
+		r.resumeReconcile(context.Background(), astraConnector)
+
+		paused := astraConnector.GetCondition(v1.ConditionPaused)
+		assert.Equal(t, metav1.ConditionFalse, paused.Status)
+		assert.Equal(t, v1.ReasonResumed, paused.Reason)
+		assert.False(t, astraConnector.IsConditionTrue(v1.ConditionReady))
+		// The cluster is detected again on the resumed reconcile
+		assert.Equal(t, int64(0), astraConnector.Status.Cluster.ObservedGeneration)
+		assert.Equal(t, []string{"Normal Resumed " + ReconcileResumed}, drainEvents(recorder))
+	})
+}
+
+func TestPausedChanged(t *testing.T) {
+	notPaused := newEventsAstraConnector()
+	paused := newEventsAstraConnector()
+	paused.Annotations = map[string]string{v1.PausedAnnotation: "true"}
+	relabeled := newEventsAstraConnector()
+	relabeled.Labels = map[string]string{"team": "storage"}
+
+	assert.True(t, pausedChanged.Update(event.UpdateEvent{ObjectOld: notPaused, ObjectNew: paused}))
+	assert.True(t, pausedChanged.Update(event.UpdateEvent{ObjectOld: paused, ObjectNew: notPaused}))
+	assert.False(t, pausedChanged.Update(event.UpdateEvent{ObjectOld: notPaused, ObjectNew: relabeled}))
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package controllers
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/NetApp-Polaris/astra-connector-operator/app/conf"
+	"github.com/NetApp-Polaris/astra-connector-operator/app/deployer/model"
+	"github.com/NetApp-Polaris/astra-connector-operator/app/deployer/neptune"
+	"github.com/NetApp-Polaris/astra-connector-operator/details/k8s"
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+)
+
+// RenderResources Writes every resource the operator creates for the AstraConnector as multi-document YAML,
+// in the order a reconcile creates them, without contacting the cluster.
+// The AutoSupportBundleSchedule is named after spec.astra.clusterId, as it is once the cluster is managed.
+func RenderResources(ctx context.Context, astraConnector *v1.AstraConnector, scheme *runtime.Scheme, w io.Writer) error {
+	objects, err := getRenderedObjects(ctx, astraConnector)
+	if err != nil {
+		return err
+	}
+
+	for i, object := range objects {
+		err = k8s.SetOwner(object, astraConnector, scheme)
+		if err != nil {
+			return errors.Wrapf(err, "unable to set owner of %s", object.GetName())
+		}
+
+		applyObject, err := k8s.ToApplyObject(object, scheme)
+		if err != nil {
+			return errors.Wrapf(err, "unable to convert %s", object.GetName())
+		}
+
+		document, err := yaml.Marshal(applyObject.Object)
+		if err != nil {
+			return errors.Wrapf(err, "unable to render %s", object.GetName())
+		}
+
+		if i > 0 {
+			document = append([]byte("---\n"), document...)
+		}
+		if _, err = w.Write(document); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getRenderedObjects Returns the objects of the deployers enabled by the feature flags and the ASUP CR,
+// as they are sent to the API server on create
+func getRenderedObjects(ctx context.Context, astraConnector *v1.AstraConnector) ([]client.Object, error) {
+	var deployers []model.Deployer
+	if conf.Config.FeatureFlags().DeployNeptune() {
+		deployers = append(deployers, neptune.NewNeptuneClientDeployerV2())
+	}
+	if conf.Config.FeatureFlags().DeployNatsConnector() {
+		deployers = append(deployers, getDeployers()...)
+	}
+
+	var objects []client.Object
+	for _, deployer := range deployers {
+		serverSideApply := model.UsesServerSideApply(deployer)
+		for _, funcList := range resources {
+			resourceList, mutateFunc, err := funcList.getResource(deployer, astraConnector, ctx)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Unable to get resource")
+			}
+
+			for _, kubeObject := range resourceList {
+				// CreateOrUpdate runs the MutateFn on a new object before creating it
+				if !serverSideApply && mutateFunc != nil {
+					if err = mutateFunc(); err != nil {
+						return nil, err
+					}
+				}
+				objects = append(objects, kubeObject)
+			}
+		}
+	}
+
+	if conf.Config.FeatureFlags().DeployNatsConnector() {
+		asupCR, mutateFn := getASUPCR(astraConnector, astraConnector.Spec.Astra.ClusterId)
+		if err := mutateFn(); err != nil {
+			return nil, err
+		}
+		objects = append(objects, asupCR)
+	}
+	return objects, nil
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+
+	"github.com/NetApp-Polaris/astra-connector-operator/common"
+	"github.com/NetApp-Polaris/astra-connector-operator/details/k8s"
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+)
+
+func newRenderScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, v1.AddToScheme(scheme))
+	return scheme
+}
+
+func newRenderAstraConnector() *v1.AstraConnector {
+	return &v1.AstraConnector{
+		ObjectMeta: metav1.ObjectMeta{Name: "astra-connector", Namespace: "astra-connector"},
+		Spec: v1.AstraConnectorSpec{
+			Astra:        v1.Astra{ClusterId: "123", AccountId: "account"},
+			AstraConnect: v1.AstraConnect{Replicas: 1},
+			AutoSupport:  v1.AutoSupport{Enrolled: true},
+		},
+	}
+}
+
+func TestRenderResources(t *testing.T) {
+	t.Run("RenderResources__AllResourcesInCreateOrder", func(t *testing.T) {
+		var out bytes.Buffer
+		err := RenderResources(context.Background(), newRenderAstraConnector(), newRenderScheme(t), &out)
+		assert.NoError(t, err)
+
+		var rendered []string
+		for _, document := range strings.Split(out.String(), "---\n") {
+			object := &unstructured.Unstructured{}
+			assert.NoError(t, yaml.Unmarshal([]byte(document), &object.Object))
+			rendered = append(rendered, object.GetKind()+" "+object.GetName())
+
+			_, hasStatus := object.Object["status"]
+			assert.False(t, hasStatus)
+			if object.GetKind() == "ClusterRole" || object.GetKind() == "ClusterRoleBinding" {
+				assert.Equal(t, "astra-connector", object.GetLabels()[common.OwnerNameLabel])
+			} else {
+				assert.Equal(t, "AstraConnector", object.GetOwnerReferences()[0].Kind)
+			}
+		}
+
+		assert.Equal(t, []string{
+			"ServiceAccount neptune-controller-manager",
+			"Service neptune-controller-manager-metrics-service",
+			"Deployment neptune-controller-manager",
+			"ConfigMap astraconnect",
+			"Role astraconnect",
+			"ClusterRole astraconnect",
+			"RoleBinding astraconnect",
+			"ClusterRoleBinding astraconnect",
+			"ServiceAccount astraconnect",
+			"Deployment astraconnect",
+			"AutoSupportBundleSchedule asupbundleschedule-123",
+		}, rendered)
+	})
+
+	t.Run("RenderResources__OutputIsStable", func(t *testing.T) {
+		var first, second bytes.Buffer
+		assert.NoError(t, RenderResources(context.Background(), newRenderAstraConnector(), newRenderScheme(t), &first))
+		assert.NoError(t, RenderResources(context.Background(), newRenderAstraConnector(), newRenderScheme(t), &second))
+		assert.Equal(t, first.String(), second.String())
+	})
+
+	t.Run("RenderResources__OpenShiftFlavor", func(t *testing.T) {
+		astraConnector := newRenderAstraConnector()
+		astraConnector.Status.Cluster = &v1.ClusterStatus{Flavor: k8s.FlavorOpenShift}
+
+		var out bytes.Buffer
+		assert.NoError(t, RenderResources(context.Background(), astraConnector, newRenderScheme(t), &out))
+		assert.Contains(t, out.String(), "kind: SecurityContextConstraints")
+		assert.Contains(t, out.String(), "CLUSTER_FLAVOR")
+	})
+
+	t.Run("RenderResources__InvalidSpecReturnsError", func(t *testing.T) {
+		astraConnector := newRenderAstraConnector()
+		astraConnector.Spec.Astra.ClusterId = ""
+
+		var out bytes.Buffer
+		err := RenderResources(context.Background(), astraConnector, newRenderScheme(t), &out)
+		assert.Error(t, err)
+	})
+}
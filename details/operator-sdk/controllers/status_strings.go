@@ -11,11 +11,20 @@ const (
 	CreateClusterRole        = "Creating ClusterRole %s/%s"
 	CreateClusterRoleBinding = "Creating ClusterRoleBinding %s/%s"
 
+	CreateSecurityContextConstraints = "Creating SecurityContextConstraints %s/%s"
+
 	WaitForClusterManagedState = "Waiting for cluster state 'managed'"
+	WaitForResourcesReady      = "Waiting for %s to be ready"
+
+	UnregisterInProgress  = "Unregistering cluster from Astra"
+	UnregisteredFromAstra = "Unregistered from Astra"
 
 	DeleteInProgress = "AstraConnector deletion in progress"
 	DeletionComplete = "AstraConnector deletion complete"
 
+	ReconcilePaused  = "Reconcile paused by the astra.netapp.io/paused annotation"
+	ReconcileResumed = "Reconcile resumed"
+
 	ErrorCreateStatefulSets        = "Error creating StatefulSets %s/%s"
 	ErrorCreateRoleBindings        = "Error creating RoleBindings %s/%s"
 	ErrorCreateClusterRoleBindings = "Error creating ClusterRoleBindings %s/%s"
@@ -25,7 +34,9 @@ const (
 	ErrorCreateService             = "Error creating Services  %s/%s"
 	ErrorCreateRoles               = "Error creating Roles  %s/%s"
 	ErrorCreateClusterRoles        = "Error creating ClusterRoles %s/%s"
+	ErrorCreateSCCs                = "Error creating SecurityContextConstraints %s/%s"
 	ErrorClusterUnmanaged          = "Timed out waiting for cluster to become managed"
+	ErrorResourcesNotReady         = "Timed out waiting for resources to be ready"
 
 	FailedFinalizerAdd             = "Failed to add finalizer"
 	FailedFinalizerRemove          = "Failed to remove finalizer"
@@ -36,6 +47,7 @@ const (
 	EmptyLocationIDGet  = "Got an empty location ID from ConfigMap"
 
 	FailedUnRegisterNSClient = "Failed to unregister natsSyncClient"
+	ErrorUnregisterTimedOut  = "Timed out unregistering cluster from Astra"
 	FailedASUPCreation       = "Failed to create ASUP CR"
 
 	DeployedComponents  = "Deployed all the connector components"
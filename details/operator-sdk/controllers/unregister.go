@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/NetApp-Polaris/astra-connector-operator/app/conf"
+	"github.com/NetApp-Polaris/astra-connector-operator/app/metrics"
+	v1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+)
+
+// unregisterRequestTimeout bounds a single unregister attempt, including the retries made by the register package
+const unregisterRequestTimeout = 1 * time.Minute
+
+// reconcileUnregister handles spec.astra.unregister=true, the cluster is unmanaged once and nothing else is deployed
+func (r *AstraConnectorController) reconcileUnregister(ctx context.Context,
+	astraConnector *v1.AstraConnector, natsSyncClientStatus *v1.NatsSyncClientStatus) (ctrl.Result, error) {
+	log := ctrllog.FromContext(ctx)
+
+	if astraConnector.IsConditionTrue(v1.ConditionUnregistered) {
+		log.Info("Cluster already unregistered from Astra")
+		return ctrl.Result{}, nil
+	}
+
+	natsSyncClientStatus.Status = UnregisterInProgress
+	_ = r.updateAstraConnectorStatus(ctx, astraConnector, *natsSyncClientStatus)
+
+	err := r.unregisterCluster(ctx, astraConnector, natsSyncClientStatus)
+	if err != nil {
+		log.Error(err, "Failed to unregister cluster, requeueing after delay", "delay", conf.Config.ErrorTimeout())
+		astraConnector.SetCondition(v1.ConditionReady, metav1.ConditionFalse, v1.ReasonUnregisterFailed, natsSyncClientStatus.Status)
+		_ = r.updateAstraConnectorStatus(ctx, astraConnector, *natsSyncClientStatus)
+		return ctrl.Result{RequeueAfter: time.Second * conf.Config.ErrorTimeout()}, nil
+	}
+
+	astraConnector.SetCondition(v1.ConditionReady, metav1.ConditionFalse, v1.ReasonUnregisterSucceeded, UnregisteredFromAstra)
+	astraConnector.Status.ObservedGeneration = astraConnector.Generation
+	_ = r.updateAstraConnectorStatus(ctx, astraConnector, *natsSyncClientStatus)
+	return ctrl.Result{}, nil
+}
+
+// unregisterCluster unmanages the cluster in Astra and records the outcome in the Unregistered condition
+func (r *AstraConnectorController) unregisterCluster(ctx context.Context,
+	astraConnector *v1.AstraConnector, natsSyncClientStatus *v1.NatsSyncClientStatus) error {
+	log := ctrllog.FromContext(ctx)
+
+	clusterId := getRegisteredClusterId(astraConnector)
+	if clusterId == "" {
+		log.Info("No Astra cluster ID known, nothing to unregister")
+		astraConnector.SetCondition(v1.ConditionUnregistered, metav1.ConditionTrue, v1.ReasonUnregisterSucceeded, "No cluster registered with Astra")
+		return nil
+	}
+
+	unregisterCtx, cancel := context.WithTimeout(ctx, unregisterRequestTimeout)
+	defer cancel()
+
+	registerUtil, err := newClusterRegisterUtil(unregisterCtx, astraConnector, r.Client, log)
+	if err != nil {
+		natsSyncClientStatus.Status = fmt.Sprintf("%s: %s", FailedUnRegisterNSClient, err.Error())
+		astraConnector.SetCondition(v1.ConditionUnregistered, metav1.ConditionFalse, v1.ReasonUnregisterFailed, natsSyncClientStatus.Status)
+		r.recordWarning(astraConnector, EventReasonUnregisterFailed, "%s", natsSyncClientStatus.Status)
+		return err
+	}
+	defer registerUtil.CloseIdleConnections()
+
+	errorReason, err := registerUtil.UnmanageCluster(clusterId)
+	if err != nil {
+		natsSyncClientStatus.Status = fmt.Sprintf("%s: %s", FailedUnRegisterNSClient, errorReason)
+		astraConnector.SetCondition(v1.ConditionUnregistered, metav1.ConditionFalse, v1.ReasonUnregisterFailed, natsSyncClientStatus.Status)
+		r.recordWarning(astraConnector, EventReasonUnregisterFailed, "%s", natsSyncClientStatus.Status)
+		return err
+	}
+
+	natsSyncClientStatus.Registered = "false"
+	natsSyncClientStatus.Status = UnregisteredFromAstra
+	metrics.SetConnectorRegistered(astraConnector.Namespace, astraConnector.Name, false)
+	r.recordEvent(astraConnector, EventReasonUnregistered, "Cluster %s unmanaged in Astra", clusterId)
+	astraConnector.SetCondition(v1.ConditionUnregistered, metav1.ConditionTrue, v1.ReasonUnregisterSucceeded, UnregisteredFromAstra)
+	astraConnector.SetCondition(v1.ConditionClusterManaged, metav1.ConditionFalse, v1.ReasonUnregisterSucceeded, UnregisteredFromAstra)
+	return nil
+}
+
+// getRegisteredClusterId returns the Astra cluster ID to unregister. The ID last registered is preferred, the spec
+// may already point at a new cluster that was never registered.
+func getRegisteredClusterId(astraConnector *v1.AstraConnector) string {
+	if astraConnector.Status.NatsSyncClient.AstraClusterId != "" {
+		return astraConnector.Status.NatsSyncClient.AstraClusterId
+	}
+	return astraConnector.Spec.Astra.ClusterId
+}
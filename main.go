@@ -22,8 +22,10 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	"github.com/NetApp-Polaris/astra-connector-operator/app/conf"
+	"github.com/NetApp-Polaris/astra-connector-operator/app/metrics"
 	astrav1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
 	"github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/controllers"
 	//+kubebuilder:scaffold:imports
@@ -44,6 +46,10 @@ func init() {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == renderCommand {
+		os.Exit(runRender(os.Args[2:]))
+	}
+
 	host := conf.Config.Host()
 	metricsAddr := fmt.Sprintf("%v:%v", host, conf.Config.MetricsPort())   // :8080
 	probeAddr := fmt.Sprintf("%v:%v", host, conf.Config.HealthProbePort()) // :8081
@@ -77,6 +83,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Serve the operator's metrics on the metrics endpoint of the manager
+	if err = metrics.Register(ctrlmetrics.Registry); err != nil {
+		setupLog.Error(err, "unable to register metrics")
+		os.Exit(1)
+	}
+
 	// Create the dynamic client
 	dynamicClient, err := dynamic.NewForConfig(ctrl.GetConfigOrDie())
 	if err != nil {
@@ -97,11 +109,19 @@ func main() {
 		Clientset:     clientset,
 		Scheme:        mgr.GetScheme(),
 		DynamicClient: dynamicClient,
+		Recorder:      mgr.GetEventRecorderFor("astra-connector-operator"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AstraConnector")
 		os.Exit(1)
 	}
 
+	if conf.Config.FeatureFlags().EnableWebhooks() {
+		if err = (&astrav1.AstraConnector{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "AstraConnector")
+			os.Exit(1)
+		}
+	}
+
 	//+kubebuilder:scaffold:builder
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
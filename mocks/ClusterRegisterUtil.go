@@ -9,6 +9,11 @@ type ClusterRegisterUtil struct {
 	mock.Mock
 }
 
+// CloseIdleConnections provides a mock function with given fields:
+func (_m *ClusterRegisterUtil) CloseIdleConnections() {
+	_m.Called()
+}
+
 // GetAPITokenFromSecret provides a mock function with given fields: secretName
 func (_m *ClusterRegisterUtil) GetAPITokenFromSecret(secretName string) (string, string, error) {
 	ret := _m.Called(secretName)
@@ -37,6 +42,29 @@ func (_m *ClusterRegisterUtil) GetAPITokenFromSecret(secretName string) (string,
 	return r0, r1, r2
 }
 
+// GetCABundle provides a mock function with given fields:
+func (_m *ClusterRegisterUtil) GetCABundle() ([]byte, error) {
+	ret := _m.Called()
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func() []byte); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsClusterManaged provides a mock function with given fields:
 func (_m *ClusterRegisterUtil) IsClusterManaged() (bool, string, error) {
 	ret := _m.Called()
@@ -79,6 +107,27 @@ func (_m *ClusterRegisterUtil) SetHttpClient(disableTls bool, astraHost string)
 	return r0
 }
 
+// UnmanageCluster provides a mock function with given fields: clusterId
+func (_m *ClusterRegisterUtil) UnmanageCluster(clusterId string) (string, error) {
+	ret := _m.Called(clusterId)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(clusterId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(clusterId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewClusterRegisterUtil interface {
 	mock.TestingT
 	Cleanup(func())
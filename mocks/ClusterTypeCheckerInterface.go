@@ -10,7 +10,7 @@ type ClusterTypeCheckerInterface struct {
 }
 
 // DetermineClusterType provides a mock function with given fields:
-func (_m *ClusterTypeCheckerInterface) DetermineClusterType() string {
+func (_m *ClusterTypeCheckerInterface) DetermineClusterType() (string, error) {
 	ret := _m.Called()
 
 	var r0 string
@@ -20,7 +20,35 @@ func (_m *ClusterTypeCheckerInterface) DetermineClusterType() string {
 		r0 = ret.Get(0).(string)
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOpenshiftVersion provides a mock function with given fields:
+func (_m *ClusterTypeCheckerInterface) GetOpenshiftVersion() (string, error) {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 type mockConstructorTestingTNewClusterTypeCheckerInterface interface {
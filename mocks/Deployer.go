@@ -211,6 +211,38 @@ func (_m *Deployer) GetRoleObjects(m *v1.AstraConnector, ctx context.Context) ([
 	return r0, r1, r2
 }
 
+// GetSecurityContextConstraintsObjects provides a mock function with given fields: m, ctx
+func (_m *Deployer) GetSecurityContextConstraintsObjects(m *v1.AstraConnector, ctx context.Context) ([]client.Object, controllerutil.MutateFn, error) {
+	ret := _m.Called(m, ctx)
+
+	var r0 []client.Object
+	if rf, ok := ret.Get(0).(func(*v1.AstraConnector, context.Context) []client.Object); ok {
+		r0 = rf(m, ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]client.Object)
+		}
+	}
+
+	var r1 controllerutil.MutateFn
+	if rf, ok := ret.Get(1).(func(*v1.AstraConnector, context.Context) controllerutil.MutateFn); ok {
+		r1 = rf(m, ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(controllerutil.MutateFn)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*v1.AstraConnector, context.Context) error); ok {
+		r2 = rf(m, ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetServiceAccountObjects provides a mock function with given fields: m, ctx
 func (_m *Deployer) GetServiceAccountObjects(m *v1.AstraConnector, ctx context.Context) ([]client.Object, controllerutil.MutateFn, error) {
 	ret := _m.Called(m, ctx)
@@ -19,6 +19,50 @@ type K8sUtilInterface struct {
 	mock.Mock
 }
 
+// APIGroupsGet provides a mock function with given fields:
+func (_m *K8sUtilInterface) APIGroupsGet() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ApplyResource provides a mock function with given fields: _a0, _a1, _a2
+func (_m *K8sUtilInterface) ApplyResource(_a0 context.Context, _a1 client.Object, _a2 client.Object) (string, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, client.Object, client.Object) string); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, client.Object, client.Object) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateOrUpdateResource provides a mock function with given fields: _a0, _a1, _a2, _a3
 func (_m *K8sUtilInterface) CreateOrUpdateResource(_a0 context.Context, _a1 client.Object, _a2 client.Object, _a3 controllerutil.MutateFn) (string, error) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
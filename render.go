@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2024. NetApp, Inc. All Rights Reserved.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"sigs.k8s.io/yaml"
+
+	astrav1 "github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/api/v1"
+	"github.com/NetApp-Polaris/astra-connector-operator/details/operator-sdk/controllers"
+)
+
+const renderCommand = "render"
+
+// runRender Prints the resources the operator creates for an AstraConnector manifest, without contacting the cluster.
+// The cluster is not detected, the resources are rendered for the flavor given with -flavor.
+// Usage: manager render -f astra_v1_astraconnector.yaml [-n astra-connector] [-flavor openshift]
+func runRender(args []string) int {
+	flags := flag.NewFlagSet(renderCommand, flag.ContinueOnError)
+	filename := flags.String("f", "-", "The AstraConnector manifest to render, - reads it from stdin.")
+	namespace := flags.String("n", "astra-connector", "The namespace of the AstraConnector if the manifest does not set one.")
+	flavor := flags.String("flavor", "", "The cluster flavor to render the resources for, e.g. openshift. "+
+		"If empty, they are rendered as for a cluster whose flavor is not detected.")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	var manifest []byte
+	var err error
+	if *filename == "-" {
+		manifest, err = io.ReadAll(os.Stdin)
+	} else {
+		manifest, err = os.ReadFile(*filename)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to read %s: %v\n", *filename, err)
+		return 1
+	}
+
+	astraConnector := &astrav1.AstraConnector{}
+	if err = yaml.UnmarshalStrict(manifest, astraConnector); err != nil {
+		fmt.Fprintf(os.Stderr, "unable to parse AstraConnector: %v\n", err)
+		return 1
+	}
+	if astraConnector.Namespace == "" {
+		astraConnector.Namespace = *namespace
+	}
+	if *flavor != "" {
+		astraConnector.Status.Cluster = &astrav1.ClusterStatus{Flavor: *flavor}
+	}
+
+	if err = controllers.RenderResources(context.Background(), astraConnector, scheme, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "unable to render resources: %v\n", err)
+		return 1
+	}
+	return 0
+}